@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
 	"github.com/im-kulikov/go-bones/logger"
 	"github.com/im-kulikov/go-bones/tracer"
 	"github.com/im-kulikov/go-bones/web"
@@ -20,8 +22,11 @@ type Base struct {
 	Tracer tracer.Config `env:"TRACER"`
 }
 
-// Validate allows to validate base config and common libraries configs.
+// Validate allows to validate base config and common libraries configs,
+// errors are keyed by field names, so they could be translated into env names.
 func (b Base) Validate(ctx context.Context) error {
+	errs := make(validation.Errors)
+
 	val := reflect.ValueOf(&b).Elem()
 	for i := 0; i < val.NumField(); i++ {
 		tmp, ok := val.Field(i).Addr().Interface().(Config)
@@ -30,9 +35,9 @@ func (b Base) Validate(ctx context.Context) error {
 		}
 
 		if err := tmp.Validate(ctx); err != nil {
-			return err
+			errs[val.Type().Field(i).Name] = err
 		}
 	}
 
-	return nil
+	return errs.Filter()
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cristalhq/aconfig"
 	"github.com/cristalhq/aconfig/aconfigdotenv"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 
 	"github.com/im-kulikov/go-bones/logger"
 )
@@ -40,6 +41,7 @@ var (
 	errShowHelp     = errors.New("show help")
 	errValidate     = errors.New("validate")
 	errMarkdown     = errors.New("markdown")
+	errSchema       = errors.New("schema")
 	errFailValidate = errors.New("could not validate config")
 )
 
@@ -48,6 +50,10 @@ func (c *config) generateDefaultEnvs(field aconfig.Field) bool {
 	names := field.Tag("env")
 	usage := field.Tag("usage")
 
+	if allowed := enumValues(field.Tag(enumTag)); len(allowed) > 0 {
+		usage = strings.TrimSpace(usage + " (one of: " + strings.Join(allowed, ", ") + ")")
+	}
+
 	current := field
 	if value == "" {
 		value = "<empty>"
@@ -70,7 +76,8 @@ func (c *config) generateDefaultEnvs(field aconfig.Field) bool {
 	_, _ = line.WriteString(value)
 
 	if usage != "" {
-		_, _ = line.WriteString(strings.Repeat(" ", pad-line.Len()))
+		// long values (e.g. lists) could be longer than padding
+		_, _ = line.WriteString(strings.Repeat(" ", max(pad-line.Len(), 1)))
 		_, _ = line.WriteString("# " + usage)
 	}
 
@@ -99,7 +106,16 @@ func (c *config) loadConfig(ctx context.Context, cfg Config) (err error) {
 		return fmt.Errorf("could not get current directory: %w", err)
 	}
 
-	c.envs = append(os.Environ(), c.envs...)
+	// we should not fail before flags will be parsed (e.g. on --help or --encrypt)
+	// precedence: defaults < .env file < sources < process envs < custom envs
+	sourced, errSources := c.loadSources(ctx)
+	if errSources != nil {
+		errSources = fmt.Errorf("could not load config sources: %w", errSources)
+	}
+
+	c.envs = append(append(sourced, os.Environ()...), c.envs...)
+
+	errDecrypt := c.decryptEnvs(cfg)
 
 	loader := aconfig.LoaderFor(cfg, aconfig.Config{
 		AllowUnknownFields: true,
@@ -107,7 +123,7 @@ func (c *config) loadConfig(ctx context.Context, cfg Config) (err error) {
 		Envs:               c.envs,
 		Files:              []string{path.Join(c.envPath, ".env")},
 		FileDecoders: map[string]aconfig.FileDecoder{
-			".env": aconfigdotenv.New(),
+			".env": &decryptDecoder{FileDecoder: aconfigdotenv.New(), decrypt: c.decrypt},
 		},
 	})
 
@@ -139,9 +155,37 @@ func (c *config) loadConfig(ctx context.Context, cfg Config) (err error) {
 			c.exit(0)
 
 			err = errMarkdown
+		case c.schema:
+			// on JSON schema requested
+			if err = c.generateSchema(cfg); err != nil {
+				c.fatalf("could not generate schema: %s", err)
+
+				c.exit(1)
+
+				return
+			}
+
+			c.exit(0)
+
+			err = errSchema
+		case c.encrypt:
+			// on encrypt requested
+			if err = c.encryptInput(); err != nil {
+				c.fatalf("could not encrypt value: %s", err)
+
+				c.exit(1)
+
+				return
+			}
+
+			c.exit(0)
+
+			err = errEncrypt
 		case c.validate:
 			// on validate requested
-			if err = cfg.Validate(ctx); err != nil {
+			if err = c.checkConfig(ctx, cfg); err != nil {
+				renderErrors(c.out, err)
+
 				c.fatalf("could not validate config: %s", err)
 
 				c.exit(2)
@@ -166,6 +210,10 @@ func (c *config) loadConfig(ctx context.Context, cfg Config) (err error) {
 		}
 	}()
 
+	if err = errors.Join(errSources, errDecrypt); err != nil {
+		return
+	}
+
 	err = loader.Load()
 
 	return
@@ -184,6 +232,7 @@ func Load(ctx context.Context, cfg Config, opts ...Option) error {
 
 	options := config{
 		pwd:  os.Getwd,
+		in:   os.Stdin,
 		out:  os.Stdout,
 		exit: os.Exit,
 		args: os.Args[1:],
@@ -200,5 +249,48 @@ func Load(ctx context.Context, cfg Config, opts ...Option) error {
 		return fmt.Errorf("could not load config: %w", err)
 	}
 
-	return cfg.Validate(ctx)
+	return options.checkConfig(ctx, cfg)
+}
+
+// checkConfig checks enum fields, passed config and collisions of its listeners,
+// validation errors are keyed by fully-qualified env names,
+// values of decrypted fields are hidden.
+func (c *config) checkConfig(ctx context.Context, cfg Config) error {
+	enums := checkEnums(cfg, c.enumIgnoreCase)
+
+	err := cfg.Validate(ctx)
+	if err != nil {
+		err = translateErrors(cfg, err)
+	}
+
+	errs := make(validation.Errors)
+	if err != nil && !errors.As(err, &errs) {
+		return err
+	}
+
+	// listeners are checked even when validation failed, so all collisions are reported at once
+	var listeners validation.Errors
+	if errors.As(checkListeners(cfg), &listeners) {
+		for name, item := range listeners {
+			if _, ok := errs[name]; !ok {
+				errs[name] = item
+			}
+		}
+	}
+
+	for name, item := range enums {
+		errs[name] = item
+	}
+
+	for name, item := range errs {
+		var fieldErr FieldError
+		if _, ok := c.secrets[name]; !ok || !errors.As(item, &fieldErr) {
+			continue
+		}
+
+		fieldErr.Value, fieldErr.Secret = "", true
+		errs[name] = fieldErr
+	}
+
+	return errs.Filter()
 }
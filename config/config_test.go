@@ -103,17 +103,45 @@ func TestNew(t *testing.T) {
 
 			expect: Base{
 				Shutdown: time.Second * 5,
-				Logger:   logger.Config{Level: "info", Trace: "fatal", SampleRate: &defaultSampleRate},
-				Tracer:   tracer.Config{Type: "jaeger", Jaeger: tracer.Jaeger{Sampler: 1, RetryInterval: time.Second * 15}},
+				Logger: logger.Config{
+					Level:        "info",
+					Trace:        "fatal",
+					SampleRate:   &defaultSampleRate,
+					Redact:       "password,secret,*token,*_secret,api_key,authorization,cookie",
+					Outputs:      "stderr",
+					ErrorOutputs: "stderr",
+					Encoding:     "json",
+					Encoder: logger.EncoderConfig{
+						MessageKey:    "msg",
+						LevelKey:      "level",
+						TimeKey:       "ts",
+						NameKey:       "logger",
+						CallerKey:     "caller",
+						StacktraceKey: "stacktrace",
+						TimeFormat:    "iso8601",
+						LevelCase:     "lower",
+					},
+					File:     logger.FileConfig{MaxSize: 100},
+					Async:    logger.AsyncConfig{BufferSize: 1024, FlushInterval: time.Second, Policy: "block"},
+					Syslog:   logger.SyslogConfig{Network: "udp", Facility: "user"},
+					Journald: logger.JournaldConfig{Socket: "/run/systemd/journal/socket"},
+					Report: logger.ReportConfig{
+						Format: "webhook", Level: "error", BatchSize: 10,
+						Interval: time.Second * 5, RateLimit: 60, Timeout: time.Second * 5,
+					},
+				},
+				Tracer: tracer.Config{Type: "jaeger", Jaeger: tracer.Jaeger{Sampler: 1, RetryInterval: time.Second * 15}},
 
 				Ops: web.OpsConfig{
 					Address: ":8081",
 					Network: "tcp",
 					NoTrace: true,
 
-					MetricsPath: "/metrics",
-					HealthyPath: "/healthy",
-					ProfilePath: "/debug/pprof",
+					MetricsPath:  "/metrics",
+					HealthyPath:  "/healthy",
+					ProfilePath:  "/debug/pprof",
+					LogLevelPath: "/log/level",
+					LogsPath:     "/debug/logs",
 				},
 			},
 		},
@@ -123,17 +151,45 @@ func TestNew(t *testing.T) {
 
 			expect: Base{
 				Shutdown: time.Second * 5,
-				Logger:   logger.Config{Level: "info", Trace: "fatal", SampleRate: &defaultSampleRate},
-				Tracer:   tracer.Config{Type: "jaeger", Jaeger: tracer.Jaeger{Sampler: 1, RetryInterval: time.Second * 15}},
+				Logger: logger.Config{
+					Level:        "info",
+					Trace:        "fatal",
+					SampleRate:   &defaultSampleRate,
+					Redact:       "password,secret,*token,*_secret,api_key,authorization,cookie",
+					Outputs:      "stderr",
+					ErrorOutputs: "stderr",
+					Encoding:     "json",
+					Encoder: logger.EncoderConfig{
+						MessageKey:    "msg",
+						LevelKey:      "level",
+						TimeKey:       "ts",
+						NameKey:       "logger",
+						CallerKey:     "caller",
+						StacktraceKey: "stacktrace",
+						TimeFormat:    "iso8601",
+						LevelCase:     "lower",
+					},
+					File:     logger.FileConfig{MaxSize: 100},
+					Async:    logger.AsyncConfig{BufferSize: 1024, FlushInterval: time.Second, Policy: "block"},
+					Syslog:   logger.SyslogConfig{Network: "udp", Facility: "user"},
+					Journald: logger.JournaldConfig{Socket: "/run/systemd/journal/socket"},
+					Report: logger.ReportConfig{
+						Format: "webhook", Level: "error", BatchSize: 10,
+						Interval: time.Second * 5, RateLimit: 60, Timeout: time.Second * 5,
+					},
+				},
+				Tracer: tracer.Config{Type: "jaeger", Jaeger: tracer.Jaeger{Sampler: 1, RetryInterval: time.Second * 15}},
 
 				Ops: web.OpsConfig{
 					Address: ":8081",
 					Network: "tcp",
 					NoTrace: true,
 
-					MetricsPath: "/metrics",
-					HealthyPath: "/healthy",
-					ProfilePath: "/debug/pprof",
+					MetricsPath:  "/metrics",
+					HealthyPath:  "/healthy",
+					ProfilePath:  "/debug/pprof",
+					LogLevelPath: "/log/level",
+					LogsPath:     "/debug/logs",
 				},
 			},
 		},
@@ -202,9 +258,12 @@ func TestNew(t *testing.T) {
 		{
 			name: "should fail on validate",
 			args: []string{"--validate"},
-			envs: []string{"LOGGER_SAMPLE_RATE=0"},
+			envs: []string{"LOGGER_SAMPLE_RATE=0", "LOGGER_LEVEL=unknown"},
 			code: 2,
 
+			out: "NAME                VALUE    ALLOWED                                   ERROR\n" +
+				"LOGGER_LEVEL        unknown  info,debug,warn,error,dpanic,panic,fatal  must be a valid value\n" +
+				"LOGGER_SAMPLE_RATE  0                                                  cannot be blank",
 			err: fmt.Errorf("could not load config: %w", errFailValidate),
 		},
 	}
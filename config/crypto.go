@@ -0,0 +1,252 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/cristalhq/aconfig"
+)
+
+// EncryptedPrefix is a prefix of encrypted config values, e.g. `enc:v1:<base64>`.
+const EncryptedPrefix = "enc:v1:"
+
+const (
+	// EnvDecryptKey allows to pass base64 encoded AES key (16, 24 or 32 bytes).
+	EnvDecryptKey = "CONFIG_DECRYPT_KEY"
+	// EnvDecryptKeyFile allows to pass path to the file that contains base64 encoded AES key.
+	EnvDecryptKeyFile = "CONFIG_DECRYPT_KEY_FILE"
+)
+
+var (
+	errEncrypt          = errors.New("encrypt")
+	errEmptyDecryptKey  = errors.New("decrypt key is not set")
+	errMalformedEncrypt = errors.New("malformed encrypted value")
+)
+
+type decryptDecoder struct {
+	aconfig.FileDecoder
+
+	decrypt func(name, value string) (string, error)
+}
+
+// ParseKey decodes base64 encoded AES key, key should be 16, 24 or 32 bytes long.
+func ParseKey(v string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode key: %w", err)
+	}
+
+	if _, err = aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, errEmptyDecryptKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts value with AES-GCM and returns it in `enc:v1:<base64>` format.
+func Encrypt(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	data := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// IsEncrypted reports whether value is in `enc:v1:<base64>` format.
+func IsEncrypted(value string) bool { return strings.HasPrefix(value, EncryptedPrefix) }
+
+// Decrypt decrypts value in `enc:v1:<base64>` format,
+// values without EncryptedPrefix are returned as is.
+func Decrypt(key []byte, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil || len(data) < gcm.NonceSize() {
+		return "", errMalformedEncrypt
+	}
+
+	out, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// Init passes file system to the wrapped decoder.
+func (d *decryptDecoder) Init(fsys fs.FS) {
+	if dec, ok := d.FileDecoder.(interface{ Init(fs.FS) }); ok {
+		dec.Init(fsys)
+	}
+}
+
+// DecodeFile decodes file and decrypts encrypted values.
+func (d *decryptDecoder) DecodeFile(filename string) (map[string]interface{}, error) {
+	out, err := d.FileDecoder.DecodeFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, val := range out {
+		value, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		if out[name], err = d.decrypt(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// lookupEnv returns the last value of env from passed list.
+func lookupEnv(envs []string, name string) (string, bool) {
+	for i := len(envs) - 1; i >= 0; i-- {
+		if key, val, ok := strings.Cut(envs[i], "="); ok && key == name {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// prepareDecryptKey resolves decrypt key from options or envs (when it was not set).
+func (c *config) prepareDecryptKey() error {
+	if len(c.decryptKey) > 0 {
+		return nil
+	}
+
+	if c.decryptKeyFile == "" {
+		if val, ok := lookupEnv(c.envs, EnvDecryptKey); ok && val != "" {
+			var err error
+			c.decryptKey, err = ParseKey(val)
+
+			return err
+		}
+
+		c.decryptKeyFile, _ = lookupEnv(c.envs, EnvDecryptKeyFile)
+	}
+
+	if c.decryptKeyFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.decryptKeyFile)
+	if err != nil {
+		return fmt.Errorf("could not read decrypt key: %w", err)
+	}
+
+	c.decryptKey, err = ParseKey(string(data))
+
+	return err
+}
+
+// decrypt decrypts value and remembers its name as secret,
+// values of envs that are unknown for the config are returned as is.
+func (c *config) decrypt(name, value string) (string, error) {
+	if _, ok := c.fields[name]; !ok || !IsEncrypted(value) {
+		return value, nil
+	}
+
+	out, err := Decrypt(c.decryptKey, value)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt %s: %w", name, err)
+	}
+
+	if c.secrets == nil {
+		c.secrets = make(map[string]struct{})
+	}
+
+	c.secrets[name] = struct{}{}
+
+	return out, nil
+}
+
+// decryptEnvs decrypts encrypted envs of fields that are loaded into passed config.
+func (c *config) decryptEnvs(cfg Config) error {
+	if err := c.prepareDecryptKey(); err != nil {
+		return err
+	}
+
+	c.fields = make(map[string]struct{})
+	walkFields(cfg, func(f field) bool {
+		c.fields[f.env] = struct{}{}
+
+		return true
+	})
+
+	for i, env := range c.envs {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || !IsEncrypted(value) {
+			continue
+		}
+
+		value, err := c.decrypt(name, value)
+		if err != nil {
+			return err
+		}
+
+		c.envs[i] = name + "=" + value
+	}
+
+	return nil
+}
+
+// encryptInput encrypts value passed to input and prints it to output.
+func (c *config) encryptInput() error {
+	if err := c.prepareDecryptKey(); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(c.in)
+	if err != nil {
+		return err
+	}
+
+	out, err := Encrypt(c.decryptKey, strings.TrimRight(string(data), "\r\n"))
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(c.out, out)
+
+	return nil
+}
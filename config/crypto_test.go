@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+)
+
+var testDecryptKey = []byte("0123456789abcdef0123456789abcdef")
+
+func customInput(v string) Option {
+	return func(c *config) { c.in = strings.NewReader(v) }
+}
+
+func mustEncrypt(t *testing.T, value string) string {
+	t.Helper()
+
+	out, err := Encrypt(testDecryptKey, value)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(out))
+
+	return out
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Run("should decrypt encrypted value", func(t *testing.T) {
+		out, err := Decrypt(testDecryptKey, mustEncrypt(t, "secret"))
+		require.NoError(t, err)
+		require.Equal(t, "secret", out)
+	})
+
+	t.Run("should return plain value as is", func(t *testing.T) {
+		out, err := Decrypt(nil, "plain")
+		require.NoError(t, err)
+		require.Equal(t, "plain", out)
+	})
+
+	t.Run("should fail on empty key", func(t *testing.T) {
+		_, err := Encrypt(nil, "secret")
+		require.ErrorIs(t, err, errEmptyDecryptKey)
+	})
+
+	t.Run("should fail on malformed value", func(t *testing.T) {
+		_, err := Decrypt(testDecryptKey, EncryptedPrefix+"%%%")
+		require.ErrorIs(t, err, errMalformedEncrypt)
+	})
+
+	t.Run("should fail on wrong key", func(t *testing.T) {
+		_, err := Decrypt([]byte("fedcba9876543210"), mustEncrypt(t, "secret"))
+		require.Error(t, err)
+	})
+
+	t.Run("should parse key", func(t *testing.T) {
+		key, err := ParseKey(base64.StdEncoding.EncodeToString(testDecryptKey) + "\n")
+		require.NoError(t, err)
+		require.Equal(t, testDecryptKey, key)
+
+		_, err = ParseKey(base64.StdEncoding.EncodeToString([]byte("short")))
+		require.Error(t, err)
+	})
+}
+
+func TestLoadEncrypted(t *testing.T) {
+	ctx := context.Background()
+	key := base64.StdEncoding.EncodeToString(testDecryptKey)
+
+	t.Run("should decrypt envs with key from env", func(t *testing.T) {
+		var cfg Base
+
+		require.NoError(t, Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithEnvs([]string{
+				EnvDecryptKey + "=" + key,
+				"LOGGER_LEVEL=" + mustEncrypt(t, "debug"),
+			})))
+
+		require.Equal(t, "debug", cfg.Logger.Level)
+	})
+
+	t.Run("should decrypt .env file with key from file", func(t *testing.T) {
+		var cfg Base
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, "key"), []byte(key), 0o600))
+		require.NoError(t, os.WriteFile(path.Join(dir, ".env"),
+			[]byte("LOGGER_TRACE="+mustEncrypt(t, "error")+"\n"), 0o600))
+
+		require.NoError(t, Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithEnvPath(dir),
+			WithEnvs([]string{EnvDecryptKeyFile + "=" + path.Join(dir, "key")})))
+
+		require.Equal(t, "error", cfg.Logger.Trace)
+	})
+
+	t.Run("should fail without key", func(t *testing.T) {
+		var cfg Base
+
+		err := Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithEnvs([]string{"LOGGER_LEVEL=" + mustEncrypt(t, "debug")}))
+
+		require.ErrorIs(t, err, errEmptyDecryptKey)
+		require.Contains(t, err.Error(), "could not decrypt LOGGER_LEVEL")
+	})
+
+	t.Run("should skip encrypted envs of unknown fields", func(t *testing.T) {
+		var cfg Base
+
+		unknown := mustEncrypt(t, "secret")
+		require.NoError(t, Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithEnvs([]string{"UNKNOWN_SECRET=" + unknown})))
+
+		t.Setenv("UNKNOWN_SECRET", unknown)
+
+		require.NoError(t, Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithDecryptKey([]byte("fedcba9876543210"))))
+	})
+
+	t.Run("should hide decrypted values in errors", func(t *testing.T) {
+		var cfg Base
+
+		err := Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithDecryptKey(testDecryptKey),
+			WithEnvs([]string{"LOGGER_LEVEL=" + mustEncrypt(t, "top-secret")}))
+
+		var errs validation.Errors
+		require.ErrorAs(t, err, &errs)
+		require.NotContains(t, err.Error(), "top-secret")
+
+		var fieldErr FieldError
+		require.True(t, errors.As(errs["LOGGER_LEVEL"], &fieldErr))
+		require.True(t, fieldErr.Secret)
+		require.Empty(t, fieldErr.Value)
+	})
+
+	t.Run("should encrypt value from input", func(t *testing.T) {
+		var cfg Base
+
+		buf := new(bytes.Buffer)
+		err := Load(ctx, &cfg,
+			customOutput(buf),
+			customInput("secret\n"),
+			WithArgs([]string{"--encrypt"}),
+			WithEnvs([]string{EnvDecryptKey + "=" + key}),
+			customExit(func(code int) { require.Zero(t, code) }))
+
+		require.ErrorIs(t, err, errEncrypt)
+
+		out, err := Decrypt(testDecryptKey, strings.TrimSpace(buf.String()))
+		require.NoError(t, err)
+		require.Equal(t, "secret", out)
+	})
+}
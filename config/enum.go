@@ -0,0 +1,68 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// enumTag allows to restrict field by closed set of values, e.g. `enum:"tcp,tcp4,tcp6,unix"`.
+const enumTag = "enum"
+
+// WithEnumIgnoreCase allows to compare values of fields with enum tag case-insensitively,
+// matched values are replaced with their canonical form (e.g. `DEBUG` with `debug`).
+func WithEnumIgnoreCase(v bool) Option {
+	return func(c *config) { c.enumIgnoreCase = v }
+}
+
+// enumValues parses enum tag into list of allowed values.
+func enumValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+
+	return items
+}
+
+// checkEnums checks that all string fields with enum tag contain one of allowed values,
+// empty values are skipped (use validation.Required to forbid them).
+func checkEnums(cfg Config, ignoreCase bool) validation.Errors {
+	errs := make(validation.Errors)
+
+	walkFields(cfg, func(f field) bool {
+		allowed := enumValues(f.info.Tag.Get(enumTag))
+		if len(allowed) == 0 || f.value.Kind() != reflect.String || f.value.String() == "" {
+			return true
+		}
+
+		current := f.value.String()
+		for _, item := range allowed {
+			switch {
+			case item == current:
+				return true
+			case ignoreCase && strings.EqualFold(item, current):
+				if f.value.CanSet() {
+					f.value.SetString(item)
+				}
+
+				return true
+			}
+		}
+
+		errs[f.env] = newFieldError(f, validation.ErrInInvalid.SetParams(map[string]interface{}{AllowedParam: allowed}))
+
+		return true
+	})
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type enumConfig struct {
+	Base
+
+	Mode  string   `env:"MODE" default:"fast" enum:"fast, slow"`
+	Kind  enumKind `env:"KIND" enum:"first,second"`
+	Count int      `env:"COUNT" default:"1" enum:"1,2"` // non string fields are ignored
+}
+
+type enumKind string
+
+func TestCheckEnums(t *testing.T) {
+	cases := []struct {
+		name   string
+		envs   []string
+		opts   []Option
+		expect enumConfig
+		errs   validation.Errors
+	}{
+		{
+			name:   "should pass on defaults",
+			expect: enumConfig{Mode: "fast", Count: 1},
+		},
+		{
+			name:   "should pass on allowed values",
+			envs:   []string{"MODE=slow", "KIND=second", "COUNT=5"},
+			expect: enumConfig{Mode: "slow", Kind: "second", Count: 5},
+		},
+		{
+			name:   "should normalize values when case is ignored",
+			envs:   []string{"MODE=SLOW", "KIND=First", "LOGGER_LEVEL=DEBUG"},
+			opts:   []Option{WithEnumIgnoreCase(true)},
+			expect: enumConfig{Mode: "slow", Kind: "first", Count: 1},
+		},
+		{
+			name: "should fail on unknown values",
+			envs: []string{"MODE=SLOW", "KIND=third", "OPS_NETWORK=udp"},
+			errs: validation.Errors{
+				"MODE":        validation.NewError("", `must be a valid value (value: "SLOW"; allowed: fast, slow)`),
+				"KIND":        validation.NewError("", `must be a valid value (value: "third"; allowed: first, second)`),
+				"OPS_NETWORK": validation.NewError("", `must be a valid value (value: "udp"; allowed: tcp, tcp4, tcp6, unix)`),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg enumConfig
+
+			err := Load(context.Background(), &cfg, append(tt.opts, WithArgs([]string{}), WithEnvs(tt.envs))...)
+			if tt.errs == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.expect.Mode, cfg.Mode)
+				require.Equal(t, tt.expect.Kind, cfg.Kind)
+				require.Equal(t, tt.expect.Count, cfg.Count)
+
+				return
+			}
+
+			var actual validation.Errors
+			require.ErrorAs(t, err, &actual)
+			require.Len(t, actual, len(tt.errs))
+
+			for name, expect := range tt.errs {
+				require.EqualError(t, actual[name], expect.Error(), name)
+			}
+		})
+	}
+
+	t.Run("should keep canonical logger level", func(t *testing.T) {
+		var cfg Base
+
+		require.NoError(t, Load(context.Background(), &cfg,
+			WithArgs([]string{}),
+			WithEnumIgnoreCase(true),
+			WithEnvs([]string{"LOGGER_LEVEL=Warn"})))
+
+		require.Equal(t, "warn", cfg.Logger.Level)
+	})
+}
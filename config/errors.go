@@ -0,0 +1,249 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// FieldError describes validation error of the config field.
+type FieldError struct {
+	// Name is a fully-qualified env name of the field.
+	Name string
+	// Value is a current value of the field (empty for secrets).
+	Value string
+	// Secret is true when field marked with `secret:"true"` tag.
+	Secret bool
+	// Allowed contains allowed values of the field, if they are known.
+	Allowed []string
+	// Err is an original validation error.
+	Err error
+}
+
+// AllowedParam is a name of validation.ErrorObject param that
+// can be used to pass allowed values into FieldError.
+const AllowedParam = "allowed"
+
+const secretTag = "secret"
+
+// Error returns validation error with offending value and allowed values.
+func (e FieldError) Error() string {
+	var details []string
+	if !e.Secret && e.Value != "" {
+		details = append(details, fmt.Sprintf("value: %q", e.Value))
+	}
+
+	if len(e.Allowed) > 0 {
+		details = append(details, "allowed: "+strings.Join(e.Allowed, ", "))
+	}
+
+	if len(details) == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(details, "; "))
+}
+
+// Unwrap returns original validation error.
+func (e FieldError) Unwrap() error { return e.Err }
+
+func newFieldError(f field, err error) FieldError {
+	var fieldErr FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr
+	}
+
+	out := FieldError{
+		Name:   f.env,
+		Err:    err,
+		Secret: f.info.Tag.Get(secretTag) == "true",
+	}
+
+	if !out.Secret {
+		out.Value = fieldValue(f.value)
+	}
+
+	var obj validation.ErrorObject
+	if errors.As(err, &obj) {
+		out.Allowed = allowedValues(obj.Params()[AllowedParam])
+	}
+
+	return out
+}
+
+func fieldValue(val reflect.Value) string {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return ""
+		}
+
+		val = val.Elem()
+	}
+
+	if !val.IsValid() || !val.CanInterface() {
+		return ""
+	}
+
+	switch val.Kind() {
+	case reflect.Struct, reflect.Map:
+		return ""
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			items = append(items, fieldValue(val.Index(i)))
+		}
+
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(val.Interface())
+	}
+}
+
+func allowedValues(param interface{}) []string {
+	if param == nil {
+		return nil
+	}
+
+	val := reflect.ValueOf(param)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return []string{fmt.Sprint(param)}
+	}
+
+	out := make([]string, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		out = append(out, fmt.Sprint(val.Index(i).Interface()))
+	}
+
+	return out
+}
+
+// lookupField searches field of the structure by its name or json tag (as ozzo-validation does),
+// promoted fields of embedded structures are also checked.
+func lookupField(parent field, name string) (field, bool) {
+	val := parent.value
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		info := typ.Field(i)
+		if !info.IsExported() {
+			continue
+		}
+
+		item := field{env: joinEnv(parent.env, envTag(info)), value: val.Field(i), info: info}
+		if info.Anonymous {
+			item.env = parent.env
+		}
+
+		for item.value.Kind() == reflect.Ptr && !item.value.IsNil() {
+			item.value = item.value.Elem()
+		}
+
+		if json := strings.Split(info.Tag.Get("json"), ",")[0]; info.Name == name || json == name {
+			return item, true
+		}
+
+		if !info.Anonymous || item.value.Kind() != reflect.Struct {
+			continue
+		}
+
+		if found, ok := lookupField(item, name); ok {
+			return found, true
+		}
+	}
+
+	return field{}, false
+}
+
+// translateErrors converts validation.Errors returned by (nested) Validate methods
+// into flat validation.Errors keyed by fully-qualified env names with FieldError values.
+func translateErrors(cfg Config, err error) error {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	root := field{value: reflect.ValueOf(cfg)}
+	for root.value.Kind() == reflect.Ptr || root.value.Kind() == reflect.Interface {
+		root.value = root.value.Elem()
+	}
+
+	if root.value.Kind() != reflect.Struct {
+		return err
+	}
+
+	out := make(validation.Errors)
+	translateNested(root, errs, out)
+
+	return out
+}
+
+func translateNested(parent field, errs validation.Errors, out validation.Errors) {
+	for name, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		current, ok := lookupField(parent, name)
+		if !ok {
+			// keys of unknown fields (e.g. already translated) are kept as is
+			current = field{env: name}
+			if parent.env != "" {
+				current.env = joinEnv(parent.env, name)
+			}
+
+			out[current.env] = newFieldError(current, err)
+
+			continue
+		}
+
+		var nested validation.Errors
+		if errors.As(err, &nested) && current.value.Kind() == reflect.Struct {
+			translateNested(current, nested, out)
+
+			continue
+		}
+
+		out[current.env] = newFieldError(current, err)
+	}
+}
+
+// renderErrors prints validation errors as a table.
+func renderErrors(w io.Writer, err error) {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return
+	}
+
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tVALUE\tALLOWED\tERROR")
+
+	for _, name := range names {
+		var fieldErr FieldError
+		if !errors.As(errs[name], &fieldErr) {
+			fieldErr = FieldError{Err: errs[name]}
+		}
+
+		value := fieldErr.Value
+		if fieldErr.Secret {
+			value = "<secret>"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			name, value, strings.Join(fieldErr.Allowed, ","), fieldErr.Err)
+	}
+
+	_ = tw.Flush()
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+)
+
+var errCustom = errors.New("custom error")
+
+type secretsConfig struct {
+	Base
+
+	Database struct {
+		DSN      string `env:"DSN" default:"postgres://localhost"`
+		Password string `env:"PASSWORD" default:"qwerty" secret:"true"`
+		PoolSize int    `json:"pool_size" default:"10"`
+	} `env:"DATABASE"`
+}
+
+func (c *secretsConfig) Validate(ctx context.Context) error {
+	errs := make(validation.Errors)
+	if err := c.Base.Validate(ctx); err != nil {
+		errs["Base"] = err
+	}
+
+	errs["Database"] = validation.Errors{
+		"DSN":       errCustom,
+		"Password":  errCustom,
+		"pool_size": validation.ErrInInvalid.SetParams(map[string]interface{}{AllowedParam: []int{1, 5}}),
+	}
+
+	return errs
+}
+
+func TestTranslateErrors(t *testing.T) {
+	var cfg secretsConfig
+
+	err := Load(context.Background(), &cfg,
+		WithArgs([]string{}),
+		WithEnvs([]string{"LOGGER_LEVEL=unknown"}))
+
+	var errs validation.Errors
+	require.ErrorAs(t, err, &errs)
+
+	require.Equal(t, validation.Errors{
+		"DATABASE_DSN": FieldError{
+			Name:  "DATABASE_DSN",
+			Value: "postgres://localhost",
+			Err:   errCustom,
+		},
+		"DATABASE_PASSWORD": FieldError{
+			Name:   "DATABASE_PASSWORD",
+			Secret: true,
+			Err:    errCustom,
+		},
+		"DATABASE_POOL_SIZE": FieldError{
+			Name:    "DATABASE_POOL_SIZE",
+			Value:   "10",
+			Allowed: []string{"1", "5"},
+			Err:     validation.ErrInInvalid.SetParams(map[string]interface{}{AllowedParam: []int{1, 5}}),
+		},
+		"LOGGER_LEVEL": FieldError{
+			Name:    "LOGGER_LEVEL",
+			Value:   "unknown",
+			Allowed: []string{"info", "debug", "warn", "error", "dpanic", "panic", "fatal"},
+			Err: validation.ErrInInvalid.SetParams(map[string]interface{}{
+				AllowedParam: []string{"info", "debug", "warn", "error", "dpanic", "panic", "fatal"},
+			}),
+		},
+	}, errs)
+
+	require.EqualError(t, errs["DATABASE_PASSWORD"], errCustom.Error())
+	require.EqualError(t, errs["DATABASE_POOL_SIZE"], `must be a valid value (value: "10"; allowed: 1, 5)`)
+	require.ErrorIs(t, errs["DATABASE_DSN"], errCustom)
+
+	t.Run("should render errors table", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		renderErrors(buf, errs)
+
+		require.Equal(t, `NAME                VALUE                 ALLOWED                                   ERROR
+DATABASE_DSN        postgres://localhost                                            custom error
+DATABASE_PASSWORD   <secret>                                                        custom error
+DATABASE_POOL_SIZE  10                    1,5                                       must be a valid value
+LOGGER_LEVEL        unknown               info,debug,warn,error,dpanic,panic,fatal  must be a valid value
+`, buf.String())
+	})
+
+	t.Run("should keep non validation errors", func(t *testing.T) {
+		require.Equal(t, errCustom, translateErrors(&cfg, errCustom))
+	})
+}
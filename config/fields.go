@@ -0,0 +1,98 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// field describes loaded config field with its fully-qualified env name.
+type field struct {
+	env   string
+	value reflect.Value
+	info  reflect.StructField
+}
+
+// walkFields calls fn for each exported field of passed config (including nested structures),
+// env names are calculated the same way as aconfig does:
+// - anonymous (embedded) structures do not add prefix;
+// - fields without env tag are named in upper snake case;
+// - fields with `env:"-"` are skipped.
+// When fn returns false, nested fields of structure will be skipped.
+func walkFields(cfg interface{}, fn func(field) bool) {
+	val := reflect.ValueOf(cfg)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
+	walkStruct("", val, fn)
+}
+
+func walkStruct(prefix string, val reflect.Value, fn func(field) bool) {
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		info := typ.Field(i)
+		if !info.IsExported() {
+			continue
+		}
+
+		name := envTag(info)
+		if name == "-" {
+			continue
+		}
+
+		item := field{env: joinEnv(prefix, name), value: val.Field(i), info: info}
+		if info.Anonymous {
+			item.env = prefix
+		}
+
+		for item.value.Kind() == reflect.Ptr && !item.value.IsNil() {
+			item.value = item.value.Elem()
+		}
+
+		if !fn(item) || item.value.Kind() != reflect.Struct {
+			continue
+		}
+
+		walkStruct(item.env, item.value, fn)
+	}
+}
+
+func joinEnv(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// envTag returns env tag of the field or generates it in upper snake case.
+func envTag(info reflect.StructField) string {
+	if tag := info.Tag.Get("env"); tag != "" {
+		return tag
+	}
+
+	var (
+		out   strings.Builder
+		runes = []rune(info.Name)
+	)
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			_, _ = out.WriteRune('_')
+		}
+
+		_, _ = out.WriteRune(unicode.ToUpper(r))
+	}
+
+	return out.String()
+}
@@ -16,6 +16,8 @@ func (c *config) attachFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.showCurr, "version", c.showCurr, "show current version")
 	fs.BoolVar(&c.validate, "validate", c.validate, "validate config")
 	fs.BoolVar(&c.markdown, "markdown", c.markdown, "generate env markdown table")
+	fs.BoolVar(&c.schema, "schema", c.schema, "generate env JSON schema")
+	fs.BoolVar(&c.encrypt, "encrypt", c.encrypt, "encrypt value from stdin with config decrypt key")
 }
 
 type flagDefinition struct {
@@ -13,8 +13,10 @@ import (
 var renderedHelp = `Usage:
 
   -V, --version    show current version
+      --encrypt    encrypt value from stdin with config decrypt key
   -h, --help       show this help message
       --markdown   generate env markdown table
+      --schema     generate env JSON schema
       --validate   validate config
 
 Default envs:
@@ -22,16 +24,60 @@ Default envs:
 SHUTDOWN_TIMEOUT=5s                               # allows to set custom graceful shutdown timeout
 OPS_ENABLED=false                                 # allows to enable ops server
 OPS_ADDRESS=:8081                                 # allows to set set ops address:port
-OPS_NETWORK=tcp                                   # allows to set ops listen network: tcp/udp
+OPS_NETWORK=tcp                                   # allows to set ops listen network (one of: tcp, tcp4, tcp6, unix)
 OPS_NO_TRACE=true                                 # allows to disable tracing
 OPS_METRICS_PATH=/metrics                         # allows to set custom metrics path
 OPS_HEALTHY_PATH=/healthy                         # allows to set custom healthy path
 OPS_PROFILE_PATH=/debug/pprof                     # allows to set custom profiler path
-LOGGER_ENCODING_CONSOLE=false                     # allows to set user-friendly formatting
-LOGGER_LEVEL=info                                 # allows to set custom logger level
-LOGGER_TRACE=fatal                                # allows to set custom trace level
+OPS_LOG_LEVEL_PATH=/log/level                     # allows to set custom logger level path
+OPS_LOGS_PATH=/debug/logs                         # allows to set custom recent logs path (logger buffer should be enabled)
+LOGGER_ENCODING_CONSOLE=false                     # allows to set user-friendly formatting (same as console encoding)
+LOGGER_ENCODING=json                              # allows to set logs encoding (one of: json, console, logfmt)
+LOGGER_LEVEL=info                                 # allows to set logger level (one of: info, debug, warn, error, dpanic, panic, fatal)
+LOGGER_TRACE=fatal                                # allows to set trace level (one of: info, debug, warn, error, dpanic, panic, fatal)
 LOGGER_SAMPLE_RATE=1000                           # allows to set sample rate
-TRACER_TYPE=jaeger                                # allows to set trace exporter type
+LOGGER_LEVELS=<empty>                             # allows to override level by logger name prefix
+LOGGER_VERBOSITY=0                                # allows to set verbosity of OpenTelemetry and gRPC internal loggers
+LOGGER_REDACT=password,secret,*token,*_secret,api_key,authorization,cookie # allows to redact keys (- disables redaction)
+LOGGER_OUTPUT_PATHS=stderr                        # allows to set outputs (std, file or sink)
+LOGGER_ERROR_OUTPUT_PATHS=stderr                  # allows to set outputs of logger internal errors
+LOGGER_DEVELOPMENT=false                          # allows to enable development mode (colored console, debug level)
+LOGGER_ENCODER_MESSAGE_KEY=msg                    # allows to set message key (- omits it)
+LOGGER_ENCODER_LEVEL_KEY=level                    # allows to set level key (- omits it)
+LOGGER_ENCODER_TIME_KEY=ts                        # allows to set time key (- omits it)
+LOGGER_ENCODER_NAME_KEY=logger                    # allows to set logger name key (- omits it)
+LOGGER_ENCODER_CALLER_KEY=caller                  # allows to set caller key (- omits it)
+LOGGER_ENCODER_STACKTRACE_KEY=stacktrace          # allows to set stacktrace key (- omits it)
+LOGGER_ENCODER_TIME_FORMAT=iso8601                # allows to set time format (one of: iso8601, rfc3339nano, epoch, millis)
+LOGGER_ENCODER_LEVEL_CASE=lower                   # allows to set level case (one of: lower, upper)
+LOGGER_ENCODER_DISABLE_CALLER=false               # allows to disable caller
+LOGGER_FILE_PATH=<empty>                          # allows to write logs into file instead of stderr
+LOGGER_FILE_MAX_SIZE=100                          # allows to set max log file size in megabytes (0 disables rotation)
+LOGGER_FILE_MAX_AGE=0s                            # allows to set max age of rotated files (0 keeps all)
+LOGGER_FILE_MAX_BACKUPS=0                         # allows to set max count of rotated files (0 keeps all)
+LOGGER_FILE_COMPRESS=false                        # allows to compress rotated files with gzip
+LOGGER_FILE_ROTATE_INTERVAL=0s                    # allows to rotate log file by interval (0 disables)
+LOGGER_ASYNC_ENABLED=false                        # allows to write logs asynchronously
+LOGGER_ASYNC_BUFFER_SIZE=1024                     # allows to set count of buffered entries
+LOGGER_ASYNC_FLUSH_INTERVAL=1s                    # allows to set flush interval (0 flushes when buffer is empty)
+LOGGER_ASYNC_POLICY=block                         # allows to set policy when buffer is full (one of: block, drop)
+LOGGER_SYSLOG_ADDRESS=<empty>                     # allows to write logs to syslog server
+LOGGER_SYSLOG_NETWORK=udp                         # allows to set syslog network (one of: udp, tcp, unix, unixgram)
+LOGGER_SYSLOG_FACILITY=user                       # allows to set syslog facility (e.g. user, daemon, local0)
+LOGGER_SYSLOG_TAG=<empty>                         # allows to set syslog app name (app name by default)
+LOGGER_JOURNALD_ENABLED=false                     # allows to write logs to journald
+LOGGER_JOURNALD_SOCKET=/run/systemd/journal/socket # allows to set journald socket path
+LOGGER_REPORT_URL=<empty>                         # allows to report errors to webhook or sentry DSN
+LOGGER_REPORT_FORMAT=webhook                      # allows to set report format (one of: webhook, sentry)
+LOGGER_REPORT_LEVEL=error                         # allows to set min reported level (one of: error, dpanic, panic, fatal)
+LOGGER_REPORT_BATCH_SIZE=10                       # allows to set count of errors sent at once
+LOGGER_REPORT_INTERVAL=5s                         # allows to set interval of sending errors
+LOGGER_REPORT_RATE_LIMIT=60                       # allows to set max count of errors per minute (0 disables limit)
+LOGGER_REPORT_TIMEOUT=5s                          # allows to set timeout of report request
+LOGGER_DEDUP_WINDOW=0s                            # allows to collapse repeated messages in window (0 disables)
+LOGGER_DEDUP_KEYS=<empty>                         # allows to set fields, that distinguish repeated messages
+LOGGER_BUFFER_SIZE=0                              # allows to keep count of recent entries in memory (0 disables)
+TRACER_TYPE=jaeger                                # allows to set trace exporter type (one of: jaeger)
 TRACER_ENABLED=false                              # allows to enable tracing
 TRACER_SAMPLER=1                                  # allows to choose sampler
 TRACER_ENDPOINT=<empty>                           # allows to set jaeger endpoint (one of)
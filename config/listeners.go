@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/im-kulikov/go-bones/web"
+)
+
+type listener struct {
+	address field
+	web.Listener
+}
+
+// checkListeners discovers all enabled listener configs (web.ListenerConfig) in passed config
+// and checks that their networks are valid and addresses do not collide with each other.
+func checkListeners(cfg Config) error {
+	var list []listener
+
+	errs := make(validation.Errors)
+
+	walkFields(cfg, func(f field) bool {
+		if f.value.Kind() != reflect.Struct || !f.value.CanInterface() {
+			return true
+		}
+
+		provider, ok := f.value.Interface().(web.ListenerConfig)
+		if !ok {
+			return true
+		}
+
+		current := provider.Listener()
+		if !current.Enabled {
+			return false
+		}
+
+		address, _ := lookupField(f, "Address")
+
+		normalized, err := current.Normalize()
+		if err != nil {
+			invalid := address
+			if !isNetworkValid(current.Network) {
+				invalid, _ = lookupField(f, "Network")
+			}
+
+			errs[invalid.env] = newFieldError(invalid, err)
+
+			return false
+		}
+
+		list = append(list, listener{address: address, Listener: normalized})
+
+		return false
+	})
+
+	for i := range list {
+		for j := 0; j < i; j++ {
+			if !list[i].Conflicts(list[j].Listener) {
+				continue
+			}
+
+			errs[list[i].address.env] = newFieldError(list[i].address,
+				fmt.Errorf("%s %s conflicts with %s", list[i].Network, list[i].Address, list[j].address.env))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func isNetworkValid(network string) bool {
+	_, err := web.Listener{Network: network, Address: ":0"}.Normalize()
+
+	return err == nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/im-kulikov/go-bones/web"
+)
+
+type listenersConfig struct {
+	Base
+
+	API  web.HTTPConfig `env:"API"`
+	GRPC web.GRPCConfig `env:"GRPC"`
+
+	Internal struct {
+		Admin *web.HTTPConfig `env:"ADMIN"`
+	} `env:"INTERNAL"`
+}
+
+func (c *listenersConfig) Validate(ctx context.Context) error { return c.Base.Validate(ctx) }
+
+func TestCheckListeners(t *testing.T) {
+	cases := []struct {
+		name string
+		envs []string
+		errs validation.Errors
+	}{
+		{
+			name: "should ignore disabled listeners",
+		},
+		{
+			name: "should be ok for different addresses",
+			envs: []string{
+				"OPS_ENABLED=true",
+				"API_ENABLED=true",
+				"GRPC_ENABLED=true",
+				"INTERNAL_ADMIN_ENABLED=true",
+				"INTERNAL_ADMIN_NETWORK=unix",
+				"INTERNAL_ADMIN_ADDRESS=/tmp/admin.sock",
+			},
+		},
+		{
+			name: "should fail on collisions",
+			envs: []string{
+				"OPS_ENABLED=true",
+				"OPS_ADDRESS=0.0.0.0:8080",
+				"API_ENABLED=true",
+				"GRPC_ENABLED=true",
+				"GRPC_ADDRESS=127.0.0.1:8080",
+			},
+			errs: validation.Errors{
+				"API_ADDRESS":  errors.New(`tcp :8080 conflicts with OPS_ADDRESS (value: ":8080")`),
+				"GRPC_ADDRESS": errors.New(`tcp 127.0.0.1:8080 conflicts with API_ADDRESS (value: "127.0.0.1:8080")`),
+			},
+		},
+		{
+			name: "should fail on collisions, when other fields are invalid",
+			envs: []string{
+				"LOGGER_VERBOSITY=-1",
+				"OPS_ENABLED=true",
+				"API_ENABLED=true",
+				"API_ADDRESS=:8081",
+			},
+			errs: validation.Errors{
+				"LOGGER_VERBOSITY": errors.New(`must be no less than 0 (value: "-1")`),
+				"API_ADDRESS":      errors.New(`tcp :8081 conflicts with OPS_ADDRESS (value: ":8081")`),
+			},
+		},
+		{
+			name: "should fail on invalid network and address",
+			envs: []string{
+				"API_ENABLED=true",
+				"API_NETWORK=udp",
+				"GRPC_ENABLED=true",
+				"GRPC_ADDRESS=localhost",
+			},
+			errs: validation.Errors{
+				"API_NETWORK":  errors.New(`must be a valid value (value: "udp"; allowed: tcp, tcp4, tcp6, unix)`),
+				"GRPC_ADDRESS": errors.New(`address localhost: missing port in address (value: "localhost")`),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg listenersConfig
+
+			err := Load(context.Background(), &cfg, WithArgs([]string{}), WithEnvs(tt.envs))
+			if tt.errs == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var actual validation.Errors
+			require.ErrorAs(t, err, &actual)
+			require.Len(t, actual, len(tt.errs))
+
+			for name, expect := range tt.errs {
+				require.EqualError(t, actual[name], expect.Error(), name)
+			}
+		})
+	}
+}
@@ -15,7 +15,7 @@ func (c *config) generateMarkdown(l *aconfig.Loader) {
 	var table [][]string
 
 	table = append(table, []string{
-		"Name", "Required", "Default value", "Usage", "Example",
+		"Name", "Required", "Default value", "Allowed values", "Usage", "Example",
 	})
 
 	sizes := make([]int, len(table[0]))
@@ -36,6 +36,7 @@ func (c *config) generateMarkdown(l *aconfig.Loader) {
 		}
 
 		examples := f.Tag("example")
+		allowed := strings.Join(enumValues(f.Tag(enumTag)), ", ")
 
 		field := f
 		var ok bool
@@ -47,7 +48,7 @@ func (c *config) generateMarkdown(l *aconfig.Loader) {
 			names = fmt.Sprintf("%s_%s", field.Tag("env"), names)
 		}
 
-		cell := []string{names, required, value, usage, examples}
+		cell := []string{names, required, value, allowed, usage, examples}
 		table = append(table, cell)
 
 		lineSize = 0
@@ -12,27 +12,71 @@ import (
 
 const renderedMarkdown = `### Envs
 
-| Name                        | Required | Default value | Usage                                          | Example                           |
-|-----------------------------|----------|---------------|------------------------------------------------|-----------------------------------|
-| SHUTDOWN_TIMEOUT            | false    | 5s            | allows to set custom graceful shutdown timeout |                                   |
-| OPS_ENABLED                 | false    | false         | allows to enable ops server                    |                                   |
-| OPS_ADDRESS                 | false    | :8081         | allows to set set ops address:port             |                                   |
-| OPS_NETWORK                 | false    | tcp           | allows to set ops listen network: tcp/udp      |                                   |
-| OPS_NO_TRACE                | false    | true          | allows to disable tracing                      |                                   |
-| OPS_METRICS_PATH            | false    | /metrics      | allows to set custom metrics path              |                                   |
-| OPS_HEALTHY_PATH            | false    | /healthy      | allows to set custom healthy path              |                                   |
-| OPS_PROFILE_PATH            | false    | /debug/pprof  | allows to set custom profiler path             |                                   |
-| LOGGER_ENCODING_CONSOLE     | false    | false         | allows to set user-friendly formatting         |                                   |
-| LOGGER_LEVEL                | false    | info          | allows to set custom logger level              |                                   |
-| LOGGER_TRACE                | false    | fatal         | allows to set custom trace level               |                                   |
-| LOGGER_SAMPLE_RATE          | false    | 1000          | allows to set sample rate                      |                                   |
-| TRACER_TYPE                 | false    | jaeger        | allows to set trace exporter type              |                                   |
-| TRACER_ENABLED              | false    | false         | allows to enable tracing                       |                                   |
-| TRACER_SAMPLER              | false    | 1             | allows to choose sampler                       |                                   |
-| TRACER_ENDPOINT             | false    |               | allows to set jaeger endpoint (one of)         | http://localhost:14268/api/traces |
-| TRACER_AGENT_HOST           | false    |               | allows to set jaeger agent host (one of)       | localhost                         |
-| TRACER_AGENT_PORT           | false    |               | allows to set jaeger agent port                | 6831                              |
-| TRACER_AGENT_RETRY_INTERVAL | false    | 15s           | allows to set retry connection timeout         |                                   |`
+| Name                          | Required | Default value                                                | Allowed values                                 | Usage                                                                   | Example                           |
+|-------------------------------|----------|--------------------------------------------------------------|------------------------------------------------|-------------------------------------------------------------------------|-----------------------------------|
+| SHUTDOWN_TIMEOUT              | false    | 5s                                                           |                                                | allows to set custom graceful shutdown timeout                          |                                   |
+| OPS_ENABLED                   | false    | false                                                        |                                                | allows to enable ops server                                             |                                   |
+| OPS_ADDRESS                   | false    | :8081                                                        |                                                | allows to set set ops address:port                                      |                                   |
+| OPS_NETWORK                   | false    | tcp                                                          | tcp, tcp4, tcp6, unix                          | allows to set ops listen network                                        |                                   |
+| OPS_NO_TRACE                  | false    | true                                                         |                                                | allows to disable tracing                                               |                                   |
+| OPS_METRICS_PATH              | false    | /metrics                                                     |                                                | allows to set custom metrics path                                       |                                   |
+| OPS_HEALTHY_PATH              | false    | /healthy                                                     |                                                | allows to set custom healthy path                                       |                                   |
+| OPS_PROFILE_PATH              | false    | /debug/pprof                                                 |                                                | allows to set custom profiler path                                      |                                   |
+| OPS_LOG_LEVEL_PATH            | false    | /log/level                                                   |                                                | allows to set custom logger level path                                  |                                   |
+| OPS_LOGS_PATH                 | false    | /debug/logs                                                  |                                                | allows to set custom recent logs path (logger buffer should be enabled) |                                   |
+| LOGGER_ENCODING_CONSOLE       | false    | false                                                        |                                                | allows to set user-friendly formatting (same as console encoding)       |                                   |
+| LOGGER_ENCODING               | false    | json                                                         | json, console, logfmt                          | allows to set logs encoding                                             |                                   |
+| LOGGER_LEVEL                  | false    | info                                                         | info, debug, warn, error, dpanic, panic, fatal | allows to set logger level                                              |                                   |
+| LOGGER_TRACE                  | false    | fatal                                                        | info, debug, warn, error, dpanic, panic, fatal | allows to set trace level                                               |                                   |
+| LOGGER_SAMPLE_RATE            | false    | 1000                                                         |                                                | allows to set sample rate                                               |                                   |
+| LOGGER_LEVELS                 | false    |                                                              |                                                | allows to override level by logger name prefix                          | grpc=warn,repo=debug              |
+| LOGGER_VERBOSITY              | false    | 0                                                            |                                                | allows to set verbosity of OpenTelemetry and gRPC internal loggers      |                                   |
+| LOGGER_REDACT                 | false    | password,secret,*token,*_secret,api_key,authorization,cookie |                                                | allows to redact keys (- disables redaction)                            |                                   |
+| LOGGER_OUTPUT_PATHS           | false    | stderr                                                       |                                                | allows to set outputs (std, file or sink)                               | stdout,app.log                    |
+| LOGGER_ERROR_OUTPUT_PATHS     | false    | stderr                                                       |                                                | allows to set outputs of logger internal errors                         |                                   |
+| LOGGER_DEVELOPMENT            | false    | false                                                        |                                                | allows to enable development mode (colored console, debug level)        |                                   |
+| LOGGER_ENCODER_MESSAGE_KEY    | false    | msg                                                          |                                                | allows to set message key (- omits it)                                  |                                   |
+| LOGGER_ENCODER_LEVEL_KEY      | false    | level                                                        |                                                | allows to set level key (- omits it)                                    |                                   |
+| LOGGER_ENCODER_TIME_KEY       | false    | ts                                                           |                                                | allows to set time key (- omits it)                                     |                                   |
+| LOGGER_ENCODER_NAME_KEY       | false    | logger                                                       |                                                | allows to set logger name key (- omits it)                              |                                   |
+| LOGGER_ENCODER_CALLER_KEY     | false    | caller                                                       |                                                | allows to set caller key (- omits it)                                   |                                   |
+| LOGGER_ENCODER_STACKTRACE_KEY | false    | stacktrace                                                   |                                                | allows to set stacktrace key (- omits it)                               |                                   |
+| LOGGER_ENCODER_TIME_FORMAT    | false    | iso8601                                                      | iso8601, rfc3339nano, epoch, millis            | allows to set time format                                               |                                   |
+| LOGGER_ENCODER_LEVEL_CASE     | false    | lower                                                        | lower, upper                                   | allows to set level case                                                |                                   |
+| LOGGER_ENCODER_DISABLE_CALLER | false    | false                                                        |                                                | allows to disable caller                                                |                                   |
+| LOGGER_FILE_PATH              | false    |                                                              |                                                | allows to write logs into file instead of stderr                        | /var/log/app.log                  |
+| LOGGER_FILE_MAX_SIZE          | false    | 100                                                          |                                                | allows to set max log file size in megabytes (0 disables rotation)      |                                   |
+| LOGGER_FILE_MAX_AGE           | false    | 0s                                                           |                                                | allows to set max age of rotated files (0 keeps all)                    |                                   |
+| LOGGER_FILE_MAX_BACKUPS       | false    | 0                                                            |                                                | allows to set max count of rotated files (0 keeps all)                  |                                   |
+| LOGGER_FILE_COMPRESS          | false    | false                                                        |                                                | allows to compress rotated files with gzip                              |                                   |
+| LOGGER_FILE_ROTATE_INTERVAL   | false    | 0s                                                           |                                                | allows to rotate log file by interval (0 disables)                      |                                   |
+| LOGGER_ASYNC_ENABLED          | false    | false                                                        |                                                | allows to write logs asynchronously                                     |                                   |
+| LOGGER_ASYNC_BUFFER_SIZE      | false    | 1024                                                         |                                                | allows to set count of buffered entries                                 |                                   |
+| LOGGER_ASYNC_FLUSH_INTERVAL   | false    | 1s                                                           |                                                | allows to set flush interval (0 flushes when buffer is empty)           |                                   |
+| LOGGER_ASYNC_POLICY           | false    | block                                                        | block, drop                                    | allows to set policy when buffer is full                                |                                   |
+| LOGGER_SYSLOG_ADDRESS         | false    |                                                              |                                                | allows to write logs to syslog server                                   | localhost:514                     |
+| LOGGER_SYSLOG_NETWORK         | false    | udp                                                          | udp, tcp, unix, unixgram                       | allows to set syslog network                                            |                                   |
+| LOGGER_SYSLOG_FACILITY        | false    | user                                                         |                                                | allows to set syslog facility (e.g. user, daemon, local0)               |                                   |
+| LOGGER_SYSLOG_TAG             | false    |                                                              |                                                | allows to set syslog app name (app name by default)                     |                                   |
+| LOGGER_JOURNALD_ENABLED       | false    | false                                                        |                                                | allows to write logs to journald                                        |                                   |
+| LOGGER_JOURNALD_SOCKET        | false    | /run/systemd/journal/socket                                  |                                                | allows to set journald socket path                                      |                                   |
+| LOGGER_REPORT_URL             | false    |                                                              |                                                | allows to report errors to webhook or sentry DSN                        | https://key@sentry.io/42          |
+| LOGGER_REPORT_FORMAT          | false    | webhook                                                      | webhook, sentry                                | allows to set report format                                             |                                   |
+| LOGGER_REPORT_LEVEL           | false    | error                                                        | error, dpanic, panic, fatal                    | allows to set min reported level                                        |                                   |
+| LOGGER_REPORT_BATCH_SIZE      | false    | 10                                                           |                                                | allows to set count of errors sent at once                              |                                   |
+| LOGGER_REPORT_INTERVAL        | false    | 5s                                                           |                                                | allows to set interval of sending errors                                |                                   |
+| LOGGER_REPORT_RATE_LIMIT      | false    | 60                                                           |                                                | allows to set max count of errors per minute (0 disables limit)         |                                   |
+| LOGGER_REPORT_TIMEOUT         | false    | 5s                                                           |                                                | allows to set timeout of report request                                 |                                   |
+| LOGGER_DEDUP_WINDOW           | false    | 0s                                                           |                                                | allows to collapse repeated messages in window (0 disables)             |                                   |
+| LOGGER_DEDUP_KEYS             | false    |                                                              |                                                | allows to set fields, that distinguish repeated messages                | error,user_id                     |
+| LOGGER_BUFFER_SIZE            | false    | 0                                                            |                                                | allows to keep count of recent entries in memory (0 disables)           |                                   |
+| TRACER_TYPE                   | false    | jaeger                                                       | jaeger                                         | allows to set trace exporter type                                       |                                   |
+| TRACER_ENABLED                | false    | false                                                        |                                                | allows to enable tracing                                                |                                   |
+| TRACER_SAMPLER                | false    | 1                                                            |                                                | allows to choose sampler                                                |                                   |
+| TRACER_ENDPOINT               | false    |                                                              |                                                | allows to set jaeger endpoint (one of)                                  | http://localhost:14268/api/traces |
+| TRACER_AGENT_HOST             | false    |                                                              |                                                | allows to set jaeger agent host (one of)                                | localhost                         |
+| TRACER_AGENT_PORT             | false    |                                                              |                                                | allows to set jaeger agent port                                         | 6831                              |
+| TRACER_AGENT_RETRY_INTERVAL   | false    | 15s                                                          |                                                | allows to set retry connection timeout                                  |                                   |`
 
 func TestMarkdown(t *testing.T) {
 	buf := new(bytes.Buffer)
@@ -11,17 +11,28 @@ type config struct {
 
 	fatalf func(string, ...interface{})
 
+	in  io.Reader
 	out io.Writer
 	pwd func() (string, error)
 
-	args []string
-	envs []string
-	exit func(int)
+	decryptKey     []byte
+	decryptKeyFile string
+	secrets        map[string]struct{}
+	fields         map[string]struct{}
+
+	enumIgnoreCase bool
+
+	args    []string
+	envs    []string
+	sources []Source
+	exit    func(int)
 
 	showHelp bool
 	showCurr bool
 	validate bool
 	markdown bool
+	schema   bool
+	encrypt  bool
 }
 
 // WithVersion allows to set current version.
@@ -43,3 +54,13 @@ func WithArgs(v []string) Option {
 func WithEnvs(v []string) Option {
 	return func(c *config) { c.envs = v }
 }
+
+// WithDecryptKey allows to set AES key that used to decrypt `enc:v1:<base64>` values.
+func WithDecryptKey(v []byte) Option {
+	return func(c *config) { c.decryptKey = v }
+}
+
+// WithDecryptKeyFile allows to set path to the file that contains base64 encoded AES key.
+func WithDecryptKeyFile(v string) Option {
+	return func(c *config) { c.decryptKeyFile = v }
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	schemaProperty struct {
+		Type        string          `json:"type"`
+		Description string          `json:"description,omitempty"`
+		Default     interface{}     `json:"default,omitempty"`
+		Enum        []string        `json:"enum,omitempty"`
+		Examples    []interface{}   `json:"examples,omitempty"`
+		Items       *schemaProperty `json:"items,omitempty"`
+	}
+
+	schemaDocument struct {
+		Schema     string                    `json:"$schema"`
+		Type       string                    `json:"type"`
+		Properties map[string]schemaProperty `json:"properties"`
+		Required   []string                  `json:"required,omitempty"`
+	}
+)
+
+// generateSchema prints JSON Schema of config envs.
+func (c *config) generateSchema(cfg Config) error {
+	doc := schemaDocument{
+		Schema:     schemaDraft,
+		Type:       "object",
+		Properties: make(map[string]schemaProperty),
+	}
+
+	walkFields(cfg, func(f field) bool {
+		typ := f.info.Type
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Struct || f.env == "" {
+			return true
+		}
+
+		prop := schemaType(typ)
+		prop.Description = f.info.Tag.Get("usage")
+		prop.Enum = enumValues(f.info.Tag.Get(enumTag))
+
+		if value, ok := f.info.Tag.Lookup("default"); ok {
+			prop.Default = schemaValue(prop, value)
+		}
+
+		if value := f.info.Tag.Get("example"); value != "" {
+			prop.Examples = []interface{}{schemaValue(prop, value)}
+		}
+
+		if f.info.Tag.Get("required") == "true" {
+			doc.Required = append(doc.Required, f.env)
+		}
+
+		doc.Properties[f.env] = prop
+
+		return true
+	})
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(c.out, string(data))
+
+	return nil
+}
+
+func schemaType(typ reflect.Type) schemaProperty {
+	if typ == reflect.TypeOf(time.Duration(0)) {
+		return schemaProperty{Type: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return schemaProperty{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schemaProperty{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schemaProperty{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		items := schemaType(typ.Elem())
+
+		return schemaProperty{Type: "array", Items: &items}
+	default:
+		return schemaProperty{Type: "string"}
+	}
+}
+
+// schemaValue converts tag value into JSON value of the property type,
+// values that could not be converted are kept as strings.
+func schemaValue(prop schemaProperty, value string) interface{} {
+	switch prop.Type {
+	case "boolean":
+		if out, err := strconv.ParseBool(value); err == nil {
+			return out
+		}
+	case "integer":
+		if out, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return out
+		}
+	case "number":
+		if out, err := strconv.ParseFloat(value, 64); err == nil {
+			return out
+		}
+	case "array":
+		items := make([]interface{}, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, schemaValue(*prop.Items, item))
+			}
+		}
+
+		return items
+	}
+
+	return value
+}
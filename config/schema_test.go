@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type schemaConfig struct {
+	Base
+
+	Hosts    []string      `env:"HOSTS" default:"a,b" usage:"list of hosts"`
+	Ratio    float64       `env:"RATIO" default:"0.5"`
+	Timeout  time.Duration `env:"TIMEOUT" default:"1s"`
+	Token    string        `env:"TOKEN" required:"true" example:"qwerty"`
+	Internal string        `env:"-"`
+}
+
+func TestSchema(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	var cfg schemaConfig
+	err := Load(context.Background(), &cfg,
+		customOutput(buf),
+		WithEnvs([]string{"TOKEN=secret"}),
+		WithArgs([]string{"--schema"}),
+		customExit(func(code int) { require.Zero(t, code) }))
+
+	require.EqualError(t, errors.Unwrap(err), errSchema.Error())
+
+	var doc schemaDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	require.Equal(t, schemaDraft, doc.Schema)
+	require.Equal(t, []string{"TOKEN"}, doc.Required)
+	require.NotContains(t, doc.Properties, "INTERNAL")
+	require.NotContains(t, doc.Properties, "LOGGER")
+
+	require.Equal(t, schemaProperty{
+		Type:        "array",
+		Description: "list of hosts",
+		Default:     []interface{}{"a", "b"},
+		Items:       &schemaProperty{Type: "string"},
+	}, doc.Properties["HOSTS"])
+
+	require.Equal(t, schemaProperty{Type: "number", Default: 0.5}, doc.Properties["RATIO"])
+	require.Equal(t, schemaProperty{Type: "string", Default: "1s"}, doc.Properties["TIMEOUT"])
+	require.Equal(t, schemaProperty{Type: "string", Examples: []interface{}{"qwerty"}}, doc.Properties["TOKEN"])
+	require.Equal(t, schemaProperty{
+		Type:        "integer",
+		Description: "allows to set sample rate",
+		Default:     float64(1000),
+	}, doc.Properties["LOGGER_SAMPLE_RATE"])
+	require.Equal(t, schemaProperty{
+		Type:        "string",
+		Description: "allows to set ops listen network",
+		Default:     "tcp",
+		Enum:        []string{"tcp", "tcp4", "tcp6", "unix"},
+	}, doc.Properties["OPS_NETWORK"])
+}
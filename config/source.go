@@ -0,0 +1,383 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Source provides config values keyed by env names.
+// Values of sources override values of .env file, but are overridden by process envs.
+// When multiple sources are registered, the latter overrides the former.
+type Source interface {
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// Watcher is an optional interface of Source, that allows to watch changes of config values.
+// Watch blocks until context will be canceled and calls fn every time values were changed.
+type Watcher interface {
+	Watch(ctx context.Context, fn func(map[string]string)) error
+}
+
+// SourceOption allows to customize file and directory sources.
+type SourceOption func(*time.Duration)
+
+// HTTPSourceOption allows to customize HTTP source.
+type HTTPSourceOption func(*httpSource)
+
+type (
+	fileSource struct {
+		path     string
+		interval time.Duration
+	}
+
+	dirSource struct {
+		path     string
+		interval time.Duration
+	}
+
+	httpSource struct {
+		url      string
+		method   string
+		body     []byte
+		prefix   string
+		header   http.Header
+		client   *http.Client
+		interval time.Duration
+	}
+
+	// consulKV represents item of Consul KV response.
+	consulKV struct {
+		Key   string `json:"Key"`
+		Value []byte `json:"Value"`
+	}
+
+	// etcdKVs represents etcd v3 range response.
+	etcdKVs struct {
+		KVs []struct {
+			Key   []byte `json:"key"`
+			Value []byte `json:"value"`
+		} `json:"kvs"`
+	}
+)
+
+const (
+	defaultHTTPSourceTimeout = time.Second * 10
+	defaultWatchInterval     = time.Second * 30
+)
+
+var (
+	_ Watcher = (*fileSource)(nil)
+	_ Watcher = (*dirSource)(nil)
+	_ Watcher = (*httpSource)(nil)
+)
+
+// WithSource allows to add custom config Source.
+func WithSource(v Source) Option {
+	return func(c *config) {
+		if v == nil {
+			return
+		}
+
+		c.sources = append(c.sources, v)
+	}
+}
+
+// FileSource creates Source that reads values from file in .env format.
+func FileSource(path string, opts ...SourceOption) Source {
+	return &fileSource{path: path, interval: watchInterval(opts)}
+}
+
+// DirSource creates Source that reads values from directory, where
+// each file name is an env name and its content is a value (e.g. Kubernetes projected volumes).
+// Hidden files (and Kubernetes `..data` directories) are ignored.
+func DirSource(path string, opts ...SourceOption) Source {
+	return &dirSource{path: path, interval: watchInterval(opts)}
+}
+
+// WithSourceInterval allows to set polling interval of file or directory source for Watch.
+func WithSourceInterval(v time.Duration) SourceOption {
+	return func(interval *time.Duration) {
+		if v <= 0 {
+			return
+		}
+
+		*interval = v
+	}
+}
+
+func watchInterval(opts []SourceOption) time.Duration {
+	interval := defaultWatchInterval
+	for _, o := range opts {
+		o(&interval)
+	}
+
+	return interval
+}
+
+// HTTPSource creates Source that reads values from HTTP JSON endpoint, supported formats:
+// - plain JSON object (`{"LOGGER_LEVEL": "debug"}`);
+// - Consul KV response (`GET /v1/kv/<prefix>?recurse=true`);
+// - etcd v3 range response (`POST /v3/kv/range`, see WithHTTPSourceRequest).
+func HTTPSource(url string, opts ...HTTPSourceOption) Source {
+	src := &httpSource{
+		url:      url,
+		method:   http.MethodGet,
+		header:   make(http.Header),
+		client:   &http.Client{Timeout: defaultHTTPSourceTimeout},
+		interval: defaultWatchInterval,
+	}
+
+	for _, o := range opts {
+		o(src)
+	}
+
+	return src
+}
+
+// WithHTTPSourceClient allows to set custom http.Client for HTTP source.
+func WithHTTPSourceClient(v *http.Client) HTTPSourceOption {
+	return func(s *httpSource) { s.client = v }
+}
+
+// WithHTTPSourceHeader allows to add custom header (e.g. X-Consul-Token) for HTTP source.
+func WithHTTPSourceHeader(key, val string) HTTPSourceOption {
+	return func(s *httpSource) { s.header.Add(key, val) }
+}
+
+// WithHTTPSourcePrefix allows to set key prefix, that will be trimmed (e.g. `app/config/`).
+func WithHTTPSourcePrefix(v string) HTTPSourceOption {
+	return func(s *httpSource) { s.prefix = v }
+}
+
+// WithHTTPSourceInterval allows to set polling interval for Watch.
+func WithHTTPSourceInterval(v time.Duration) HTTPSourceOption {
+	return func(s *httpSource) {
+		if v <= 0 {
+			return
+		}
+
+		s.interval = v
+	}
+}
+
+// WithHTTPSourceRequest allows to set request method and body for HTTP source,
+// e.g. etcd v3 range request: `POST /v3/kv/range` with `{"key": "<base64 prefix>", "range_end": "<base64 prefix end>"}`.
+func WithHTTPSourceRequest(method string, body []byte) HTTPSourceOption {
+	return func(s *httpSource) {
+		if method != "" {
+			s.method = method
+		}
+
+		s.body = body
+	}
+}
+
+// Load reads values from file.
+func (s *fileSource) Load(context.Context) (map[string]string, error) {
+	return godotenv.Read(s.path)
+}
+
+// Watch polls file for changes.
+func (s *fileSource) Watch(ctx context.Context, fn func(map[string]string)) error {
+	return watchSource(ctx, s, s.interval, fn)
+}
+
+// Load reads values from directory.
+func (s *dirSource) Load(context.Context) (map[string]string, error) {
+	list, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(list))
+	for _, item := range list {
+		if strings.HasPrefix(item.Name(), ".") {
+			continue
+		}
+
+		name := path.Join(s.path, item.Name())
+
+		// follow symlinks, because Kubernetes projected volumes contains them.
+		info, errStat := os.Stat(name)
+		if errStat != nil {
+			return nil, errStat
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		data, errRead := os.ReadFile(name)
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		out[item.Name()] = strings.TrimRight(string(data), "\r\n")
+	}
+
+	return out, nil
+}
+
+// Watch polls directory for changes.
+func (s *dirSource) Watch(ctx context.Context, fn func(map[string]string)) error {
+	return watchSource(ctx, s, s.interval, fn)
+}
+
+// Load reads values from HTTP endpoint.
+func (s *httpSource) Load(ctx context.Context) (map[string]string, error) {
+	var body io.Reader
+	if s.body != nil {
+		body = bytes.NewReader(s.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, s.method, s.url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range s.header {
+		req.Header[key] = s.header[key]
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decode(data)
+}
+
+func (s *httpSource) decode(data []byte) (map[string]string, error) {
+	out := make(map[string]string)
+
+	var consul []consulKV
+	if err := json.Unmarshal(data, &consul); err == nil {
+		for _, item := range consul {
+			if name := strings.TrimPrefix(item.Key, s.prefix); name != "" && !strings.HasSuffix(name, "/") {
+				out[name] = string(item.Value)
+			}
+		}
+
+		return out, nil
+	}
+
+	var etcd etcdKVs
+	if err := json.Unmarshal(data, &etcd); err == nil && etcd.KVs != nil {
+		for _, item := range etcd.KVs {
+			out[strings.TrimPrefix(string(item.Key), s.prefix)] = string(item.Value)
+		}
+
+		return out, nil
+	}
+
+	var plain map[string]interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, err
+	}
+
+	for key, val := range plain {
+		switch v := val.(type) {
+		case string:
+			out[strings.TrimPrefix(key, s.prefix)] = v
+		case nil:
+		default:
+			encoded, _ := json.Marshal(v)
+			out[strings.TrimPrefix(key, s.prefix)] = string(encoded)
+		}
+	}
+
+	return out, nil
+}
+
+// Watch polls HTTP endpoint for changes.
+func (s *httpSource) Watch(ctx context.Context, fn func(map[string]string)) error {
+	return watchSource(ctx, s, s.interval, fn)
+}
+
+// watchSource polls source and calls fn, when values were changed.
+// Errors are ignored, so temporary unavailability of source does not stop watching.
+func watchSource(ctx context.Context, src Source, interval time.Duration, fn func(map[string]string)) error {
+	last, err := src.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current, errLoad := src.Load(ctx)
+			if errLoad != nil || equalValues(last, current) {
+				continue
+			}
+
+			last = current
+
+			fn(current)
+		}
+	}
+}
+
+func equalValues(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, val := range a {
+		if other, ok := b[key]; !ok || other != val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// loadSources loads values from all sources and returns them as envs list.
+func (c *config) loadSources(ctx context.Context) ([]string, error) {
+	var out []string
+	for _, src := range c.sources {
+		values, err := src.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			out = append(out, name+"="+values[name])
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("file source", func(t *testing.T) {
+		dir := t.TempDir()
+		name := path.Join(dir, "app.env")
+		require.NoError(t, os.WriteFile(name, []byte("LOGGER_LEVEL=debug\n# comment\nLOGGER_TRACE=\"error\"\n"), 0o600))
+
+		values, err := FileSource(name).Load(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"LOGGER_LEVEL": "debug", "LOGGER_TRACE": "error"}, values)
+
+		_, err = FileSource(path.Join(dir, "unknown")).Load(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("directory source", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// emulate Kubernetes projected volume
+		data := path.Join(dir, "..2023_01_01")
+		require.NoError(t, os.Mkdir(data, 0o700))
+		require.NoError(t, os.WriteFile(path.Join(data, "LOGGER_LEVEL"), []byte("warn\n"), 0o600))
+		require.NoError(t, os.Symlink(data, path.Join(dir, "..data")))
+		require.NoError(t, os.Symlink(path.Join(dir, "..data", "LOGGER_LEVEL"), path.Join(dir, "LOGGER_LEVEL")))
+		require.NoError(t, os.WriteFile(path.Join(dir, ".hidden"), []byte("ignored"), 0o600))
+		require.NoError(t, os.Mkdir(path.Join(dir, "nested"), 0o700))
+
+		values, err := DirSource(dir).Load(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"LOGGER_LEVEL": "warn"}, values)
+
+		_, err = DirSource(path.Join(dir, "unknown")).Load(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("http source", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			method string
+			body   interface{}
+			expect map[string]string
+		}{
+			{
+				name:   "plain",
+				body:   map[string]interface{}{"app/LOGGER_LEVEL": "debug", "app/LOGGER_SAMPLE_RATE": 10, "app/EMPTY": nil},
+				expect: map[string]string{"LOGGER_LEVEL": "debug", "LOGGER_SAMPLE_RATE": "10"},
+			},
+			{
+				name: "consul",
+				body: []consulKV{
+					{Key: "app/", Value: nil},
+					{Key: "app/LOGGER_LEVEL", Value: []byte("debug")},
+				},
+				expect: map[string]string{"LOGGER_LEVEL": "debug"},
+			},
+			{
+				name:   "etcd",
+				method: http.MethodPost,
+				body: map[string]interface{}{"kvs": []map[string][]byte{
+					{"key": []byte("app/LOGGER_LEVEL"), "value": []byte("debug")},
+				}},
+				expect: map[string]string{"LOGGER_LEVEL": "debug"},
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				var request []byte
+				if tt.method == http.MethodPost {
+					request = []byte(`{"key":"YXBwLw==","range_end":"YXBwMA=="}`)
+				}
+
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					data, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.Equal(t, string(request), string(data))
+					require.Equal(t, "token", r.Header.Get("X-Consul-Token"))
+					require.NoError(t, json.NewEncoder(w).Encode(tt.body))
+				}))
+				defer srv.Close()
+
+				values, err := HTTPSource(srv.URL,
+					WithHTTPSourceClient(srv.Client()),
+					WithHTTPSourceRequest(tt.method, request),
+					WithHTTPSourcePrefix("app/"),
+					WithHTTPSourceHeader("X-Consul-Token", "token")).Load(ctx)
+
+				require.NoError(t, err)
+				require.Equal(t, tt.expect, values)
+			})
+		}
+
+		t.Run("should fail on unexpected status", func(t *testing.T) {
+			srv := httptest.NewServer(http.NotFoundHandler())
+			defer srv.Close()
+
+			_, err := HTTPSource(srv.URL).Load(ctx)
+			require.EqualError(t, err, "unexpected status code 404")
+		})
+	})
+
+	t.Run("should watch changes", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			level := "info"
+			if atomic.AddInt32(&calls, 1) > 2 {
+				level = "debug"
+			}
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"LOGGER_LEVEL": level}))
+		}))
+		defer srv.Close()
+
+		top, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		src := HTTPSource(srv.URL, WithHTTPSourceInterval(time.Millisecond))
+
+		watcher, ok := src.(Watcher)
+		require.True(t, ok)
+		require.NoError(t, watcher.Watch(top, func(values map[string]string) {
+			require.Equal(t, map[string]string{"LOGGER_LEVEL": "debug"}, values)
+
+			cancel()
+		}))
+		require.ErrorIs(t, top.Err(), context.Canceled)
+	})
+
+	t.Run("should stop watching on context cancel", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"LOGGER_LEVEL": "info"}))
+		}))
+		defer srv.Close()
+
+		top, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+		defer cancel()
+
+		watcher, ok := HTTPSource(srv.URL, WithHTTPSourceInterval(time.Millisecond)).(Watcher)
+		require.True(t, ok)
+		require.NoError(t, watcher.Watch(top, func(map[string]string) { require.Fail(t, "values were not changed") }))
+
+		// the first load error is returned
+		srv.Close()
+		require.Error(t, watcher.Watch(ctx, nil))
+	})
+
+	t.Run("should watch file and directory changes", func(t *testing.T) {
+		dir := t.TempDir()
+		name := path.Join(dir, "app.env")
+		require.NoError(t, os.WriteFile(name, []byte("LOGGER_LEVEL=info\n"), 0o600))
+		require.NoError(t, os.WriteFile(path.Join(dir, "LOGGER_LEVEL"), []byte("info"), 0o600))
+
+		cases := []struct {
+			src   Source
+			name  string
+			value string
+		}{
+			{src: FileSource(name, WithSourceInterval(time.Millisecond)), name: name, value: "LOGGER_LEVEL=debug\n"},
+			{src: DirSource(dir, WithSourceInterval(time.Millisecond)), name: path.Join(dir, "LOGGER_LEVEL"), value: "debug"},
+		}
+
+		for _, tt := range cases {
+			top, cancel := context.WithTimeout(ctx, time.Second)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+
+				time.Sleep(time.Millisecond * 10)
+				require.NoError(t, os.WriteFile(tt.name, []byte(tt.value), 0o600))
+			}()
+
+			var changed map[string]string
+			require.NoError(t, tt.src.(Watcher).Watch(top, func(values map[string]string) {
+				changed = values
+
+				cancel()
+			}))
+
+			<-done
+			cancel()
+			require.Equal(t, "debug", changed["LOGGER_LEVEL"])
+		}
+	})
+
+	t.Run("should respect precedence", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, ".env"), []byte("LOGGER_LEVEL=error\nLOGGER_TRACE=error\n"), 0o600))
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+				"LOGGER_TRACE":       "warn",
+				"LOGGER_SAMPLE_RATE": "5",
+			}))
+		}))
+		defer srv.Close()
+
+		var cfg Base
+		require.NoError(t, Load(ctx, &cfg,
+			WithArgs([]string{}),
+			WithEnvPath(dir),
+			WithEnvs([]string{"LOGGER_SAMPLE_RATE=10"}),
+			WithSource(nil), // should be ignored
+			WithSource(HTTPSource(srv.URL))))
+
+		require.Equal(t, "error", cfg.Logger.Level)  // .env file
+		require.Equal(t, "warn", cfg.Logger.Trace)   // source overrides .env file
+		require.Equal(t, 10, *cfg.Logger.SampleRate) // envs override source
+	})
+
+	t.Run("should fail on source error", func(t *testing.T) {
+		var cfg Base
+
+		err := Load(ctx, &cfg, WithArgs([]string{}), WithSource(DirSource(path.Join(t.TempDir(), "unknown"))))
+		require.ErrorContains(t, err, "could not load config sources")
+	})
+
+	t.Run("should not fail on help with source error", func(t *testing.T) {
+		var (
+			cfg  Base
+			code = -1
+			out  bytes.Buffer
+		)
+
+		err := Load(ctx, &cfg,
+			WithArgs([]string{"--help"}),
+			customOutput(&out),
+			customExit(func(v int) { code = v }),
+			WithSource(DirSource(path.Join(t.TempDir(), "unknown"))))
+
+		require.ErrorIs(t, err, errShowHelp)
+		require.Equal(t, 0, code)
+		require.Contains(t, out.String(), "Default envs:")
+	})
+}
@@ -0,0 +1,234 @@
+package logger
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/zapcore"
+)
+
+// AsyncConfig provides configuration of asynchronous buffered output.
+type AsyncConfig struct {
+	Enabled       bool          `env:"ENABLED" default:"false" usage:"allows to write logs asynchronously"`
+	BufferSize    int           `env:"BUFFER_SIZE" default:"1024" usage:"allows to set count of buffered entries"`
+	FlushInterval time.Duration `env:"FLUSH_INTERVAL" default:"1s" usage:"allows to set flush interval (0 flushes when buffer is empty)"`
+	Policy        string        `env:"POLICY" default:"block" enum:"block,drop" usage:"allows to set policy when buffer is full"`
+}
+
+// asyncWriter implements Sink that writes entries in background goroutine,
+// when it was stopped, entries are written synchronously.
+type asyncWriter struct {
+	mu      sync.RWMutex
+	closed  bool
+	stopped bool
+	once    sync.Once
+	direct  sync.Mutex // serializes synchronous writes after stop
+
+	out      zapcore.WriteSyncer
+	errs     zapcore.WriteSyncer // error output of the logger
+	close    func()
+	buf      *bufio.Writer
+	drop     bool
+	interval time.Duration
+
+	queue chan []byte
+	flush chan chan error
+	done  chan struct{}
+}
+
+const (
+	// AsyncPolicyBlock blocks writes when buffer is full.
+	AsyncPolicyBlock = "block"
+	// AsyncPolicyDrop drops entries when buffer is full.
+	AsyncPolicyDrop = "drop"
+
+	defaultAsyncBufferSize = 1024
+	asyncWriterBufferSize  = 256 * 1024
+)
+
+// nolint: gochecknoglobals
+var asyncDropped = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "log_async_dropped_total",
+	Help: "Count of log entries dropped by asynchronous output when buffer is full.",
+})
+
+var _ Sink = (*asyncWriter)(nil)
+
+// Validate checks that buffer size is greater than zero and flush interval is not negative.
+func (c AsyncConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.BufferSize, validation.When(c.Enabled, validation.Required, validation.Min(1))),
+		validation.Field(&c.FlushInterval, validation.Min(time.Duration(0))))
+}
+
+func newAsyncWriter(out zapcore.WriteSyncer, closer func(), cfg AsyncConfig, errs zapcore.WriteSyncer) *asyncWriter {
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultAsyncBufferSize
+	}
+
+	writer := &asyncWriter{
+		out:      out,
+		errs:     errs,
+		close:    closer,
+		buf:      bufio.NewWriterSize(out, asyncWriterBufferSize),
+		drop:     cfg.Policy == AsyncPolicyDrop,
+		interval: cfg.FlushInterval,
+
+		queue: make(chan []byte, size),
+		flush: make(chan chan error),
+		done:  make(chan struct{}),
+	}
+
+	go writer.run()
+
+	return writer
+}
+
+// Write puts copy of entry into buffer, when buffer is full it blocks or drops entry depending on policy.
+func (w *asyncWriter) Write(data []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	if w.stopped {
+		w.direct.Lock()
+		defer w.direct.Unlock()
+
+		return w.out.Write(data)
+	}
+
+	entry := make([]byte, len(data))
+	copy(entry, data)
+
+	if !w.drop {
+		w.queue <- entry
+
+		return len(data), nil
+	}
+
+	select {
+	case w.queue <- entry:
+	default:
+		asyncDropped.Inc()
+	}
+
+	return len(data), nil
+}
+
+// Sync waits until buffered entries are written and synced.
+func (w *asyncWriter) Sync() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return nil
+	}
+
+	if w.stopped {
+		return w.out.Sync()
+	}
+
+	done := make(chan error, 1)
+	w.flush <- done
+
+	return <-done
+}
+
+// stop writes buffered entries and stops background goroutine, next entries are written synchronously.
+func (w *asyncWriter) stop() {
+	w.mu.Lock()
+	if w.closed || w.stopped {
+		w.mu.Unlock()
+
+		return
+	}
+
+	w.stopped = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+}
+
+// Close writes buffered entries and closes underlying output.
+func (w *asyncWriter) Close() error {
+	w.once.Do(func() {
+		w.mu.Lock()
+		if !w.stopped {
+			close(w.queue)
+		}
+
+		w.closed = true
+		w.mu.Unlock()
+
+		<-w.done
+
+		if w.close != nil {
+			w.close()
+		}
+	})
+
+	return nil
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case entry, ok := <-w.queue:
+			if !ok {
+				w.report(w.sync())
+
+				return
+			}
+
+			w.write(entry)
+
+			if tick == nil && len(w.queue) == 0 {
+				w.report(w.buf.Flush())
+			}
+		case <-tick:
+			w.report(w.buf.Flush())
+		case done := <-w.flush:
+			for len(w.queue) > 0 {
+				w.write(<-w.queue)
+			}
+
+			done <- w.sync()
+		}
+	}
+}
+
+func (w *asyncWriter) write(entry []byte) {
+	if _, err := w.buf.Write(entry); err != nil {
+		w.report(err)
+	}
+}
+
+func (w *asyncWriter) sync() error {
+	return errors.Join(w.buf.Flush(), w.out.Sync())
+}
+
+func (w *asyncWriter) report(err error) {
+	if err != nil {
+		reportError(w.errs, "could not write logs asynchronously: %s", err)
+	}
+}
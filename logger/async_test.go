@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type lockedBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+
+	synced int
+	block  chan struct{}
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	if b.block != nil {
+		<-b.block
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	return b.Buffer.Write(p)
+}
+
+func (b *lockedBuffer) Sync() error {
+	b.Lock()
+	defer b.Unlock()
+
+	b.synced++
+
+	return nil
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.Buffer.String()
+}
+
+func TestAsyncWriter(t *testing.T) {
+	t.Run("should write entries on sync", func(t *testing.T) {
+		out := &lockedBuffer{}
+		writer := newAsyncWriter(out, nil, AsyncConfig{BufferSize: 10, FlushInterval: time.Hour}, nil)
+
+		for _, line := range []string{"first\n", "second\n"} {
+			n, err := writer.Write([]byte(line))
+			require.NoError(t, err)
+			require.Equal(t, len(line), n)
+		}
+
+		require.NoError(t, writer.Sync())
+		require.Equal(t, "first\nsecond\n", out.String())
+		require.Equal(t, 1, out.synced)
+		require.NoError(t, writer.Close())
+	})
+
+	t.Run("should flush entries by interval", func(t *testing.T) {
+		out := &lockedBuffer{}
+		writer := newAsyncWriter(out, nil, AsyncConfig{BufferSize: 10, FlushInterval: time.Millisecond}, nil)
+		defer func() { require.NoError(t, writer.Close()) }()
+
+		_, err := writer.Write([]byte("message\n"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return out.String() == "message\n" }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should flush entries when buffer is empty", func(t *testing.T) {
+		out := &lockedBuffer{}
+		writer := newAsyncWriter(out, nil, AsyncConfig{BufferSize: 10}, nil)
+		defer func() { require.NoError(t, writer.Close()) }()
+
+		_, err := writer.Write([]byte("message\n"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return out.String() == "message\n" }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should drop entries when buffer is full", func(t *testing.T) {
+		out := &lockedBuffer{block: make(chan struct{})}
+		writer := newAsyncWriter(out, nil, AsyncConfig{BufferSize: 1, FlushInterval: time.Hour, Policy: AsyncPolicyDrop}, nil)
+		before := testutil.ToFloat64(asyncDropped)
+
+		// first entry is taken by writer goroutine, second one is buffered, others are dropped
+		_, err := writer.Write(bytes.Repeat([]byte("a"), asyncWriterBufferSize+1))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return len(writer.queue) == 0 }, time.Second, time.Millisecond)
+
+		for i := 0; i < 3; i++ {
+			_, err = writer.Write([]byte("b"))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, float64(2), testutil.ToFloat64(asyncDropped)-before)
+
+		close(out.block)
+		require.NoError(t, writer.Sync())
+		require.Equal(t, asyncWriterBufferSize+2, len(out.String()))
+		require.NoError(t, writer.Close())
+	})
+
+	t.Run("should write entries and close output on close", func(t *testing.T) {
+		var closed bool
+
+		out := &lockedBuffer{}
+		writer := newAsyncWriter(out, func() { closed = true }, AsyncConfig{BufferSize: 10, FlushInterval: time.Hour}, nil)
+
+		_, err := writer.Write([]byte("message\n"))
+		require.NoError(t, err)
+
+		require.NoError(t, writer.Close())
+		require.NoError(t, writer.Close())
+		require.True(t, closed)
+		require.Equal(t, "message\n", out.String())
+
+		_, err = writer.Write([]byte("message\n"))
+		require.ErrorIs(t, err, os.ErrClosed)
+		require.NoError(t, writer.Sync())
+	})
+
+	t.Run("should write entries synchronously after stop", func(t *testing.T) {
+		var closed bool
+
+		out := &lockedBuffer{}
+		writer := newAsyncWriter(out, func() { closed = true }, AsyncConfig{BufferSize: 10, FlushInterval: time.Hour}, nil)
+
+		_, err := writer.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		writer.stop()
+		writer.stop()
+
+		select {
+		case <-writer.done:
+		default:
+			require.Fail(t, "writer goroutine should be stopped")
+		}
+
+		require.False(t, closed)
+		require.Equal(t, "first\n", out.String())
+
+		_, err = writer.Write([]byte("second\n"))
+		require.NoError(t, err)
+		require.Equal(t, "first\nsecond\n", out.String())
+		require.NoError(t, writer.Sync())
+
+		require.NoError(t, writer.Close())
+		require.True(t, closed)
+	})
+}
+
+func TestNew_Async(t *testing.T) {
+	out := &lockedBuffer{}
+
+	log, err := New(Config{Level: "info", Trace: "fatal", Async: AsyncConfig{Enabled: true, BufferSize: 10, FlushInterval: time.Hour}},
+		WithCustomOutput("async-test", &fakeSink{Writer: out}))
+	require.NoError(t, err)
+
+	log.Info("first")
+	log.Named("worker").Warn("second")
+
+	require.NoError(t, log.Sync())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"msg":"first"`)
+	require.Contains(t, lines[1], `"msg":"second"`)
+
+	// entries are written synchronously after close
+	require.NoError(t, log.Close())
+	log.Info("third")
+	require.Contains(t, out.String(), `"msg":"third"`)
+
+	t.Run("should validate async config", func(t *testing.T) {
+		require.Error(t, AsyncConfig{Enabled: true}.Validate())
+		require.Error(t, AsyncConfig{FlushInterval: -time.Second}.Validate())
+		require.NoError(t, AsyncConfig{}.Validate())
+		require.NoError(t, AsyncConfig{Enabled: true, BufferSize: 1}.Validate())
+	})
+}
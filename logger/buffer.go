@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.uber.org/zap/zapcore"
+)
+
+// BufferConfig provides configuration of in-memory ring buffer of recent entries.
+type BufferConfig struct {
+	Size int `env:"SIZE" default:"0" usage:"allows to keep count of recent entries in memory (0 disables)"`
+}
+
+type (
+	// Buffer keeps recent entries in memory, the oldest entries are overwritten by new ones.
+	Buffer struct {
+		mu      sync.RWMutex
+		entries []BufferEntry
+		next    int
+		full    bool
+
+		subscribers map[chan BufferEntry]struct{}
+	}
+
+	// BufferEntry describes entry kept in Buffer.
+	BufferEntry struct {
+		Time    time.Time              `json:"time"`
+		Level   zapcore.Level          `json:"level"`
+		Logger  string                 `json:"logger,omitempty"`
+		Message string                 `json:"message"`
+		Caller  string                 `json:"caller,omitempty"`
+		Fields  map[string]interface{} `json:"fields,omitempty"`
+	}
+
+	// bufferCore writes entries into Buffer, it should be teed with core that writes entries.
+	bufferCore struct {
+		zapcore.LevelEnabler
+
+		fields []zapcore.Field
+		buffer *Buffer
+	}
+)
+
+// bufferFollowSize is a capacity of subscriber channel, entries are skipped for slow subscribers.
+const bufferFollowSize = 256
+
+var _ zapcore.Core = (*bufferCore)(nil)
+
+// Validate checks that buffer size is not negative.
+func (c BufferConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Size, validation.Min(0)))
+}
+
+// newBuffer returns nil when size is not passed.
+func newBuffer(size int) *Buffer {
+	if size <= 0 {
+		return nil
+	}
+
+	return &Buffer{entries: make([]BufferEntry, size), subscribers: make(map[chan BufferEntry]struct{})}
+}
+
+// Entries returns kept entries with passed level and above written after since, from the oldest to the newest.
+func (b *Buffer) Entries(level zapcore.Level, since time.Time) []BufferEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	out := make([]BufferEntry, 0, len(b.entries))
+	for _, entry := range b.all() {
+		if entry.Level >= level && !entry.Time.Before(since) {
+			out = append(out, entry)
+		}
+	}
+
+	return out
+}
+
+// Subscribe returns kept entries and channel, that receives new entries until cancel is called,
+// new entries are skipped when subscriber is slow.
+func (b *Buffer) Subscribe() ([]BufferEntry, <-chan BufferEntry, func()) {
+	ch := make(chan BufferEntry, bufferFollowSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+
+	return append([]BufferEntry{}, b.all()...), ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers, ch)
+			close(ch)
+		})
+	}
+}
+
+// all returns kept entries from the oldest to the newest, should be called under lock.
+func (b *Buffer) all() []BufferEntry {
+	if !b.full {
+		return b.entries[:b.next]
+	}
+
+	return append(append(make([]BufferEntry, 0, len(b.entries)), b.entries[b.next:]...), b.entries[:b.next]...)
+}
+
+func (b *Buffer) add(entry BufferEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = entry
+	if b.next = (b.next + 1) % len(b.entries); b.next == 0 {
+		b.full = true
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+// newBufferCore returns core, that keeps all entries passed by wrapping core (levels are checked by it).
+func newBufferCore(buffer *Buffer) *bufferCore {
+	return &bufferCore{LevelEnabler: zapcore.DebugLevel, buffer: buffer}
+}
+
+// With adds structured context to the core.
+func (c *bufferCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(append(clone.fields, c.fields...), fields...)
+
+	return &clone
+}
+
+// Check adds core to checked entry, levels are checked by wrapping core.
+func (c *bufferCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write converts entry and fields into BufferEntry and keeps it in buffer.
+func (c *bufferCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	entry := BufferEntry{Time: ent.Time, Level: ent.Level, Logger: ent.LoggerName, Message: ent.Message, Fields: enc.Fields}
+	if ent.Caller.Defined {
+		entry.Caller = ent.Caller.TrimmedPath()
+	}
+
+	if len(entry.Fields) == 0 {
+		entry.Fields = nil
+	}
+
+	c.buffer.add(entry)
+
+	return nil
+}
+
+// Sync does nothing, entries are kept in memory.
+func (c *bufferCore) Sync() error { return nil }
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBufferConfig_Validate(t *testing.T) {
+	require.NoError(t, BufferConfig{}.Validate())
+	require.NoError(t, BufferConfig{Size: 100}.Validate())
+	require.EqualError(t, BufferConfig{Size: -1}.Validate(), "Size: must be no less than 0.")
+}
+
+func TestBuffer(t *testing.T) {
+	t.Run("should be disabled by default", func(t *testing.T) {
+		log, err := New(Config{Level: "info", Trace: "fatal"}, WithCustomOutput("buffer-test-disabled", &fakeSink{Writer: io.Discard}))
+		require.NoError(t, err)
+		require.Nil(t, log.Buffer())
+		require.Nil(t, ForTests(t).Buffer())
+	})
+
+	t.Run("should keep recent entries", func(t *testing.T) {
+		log, err := New(Config{Level: "info", Trace: "fatal", Redact: "password", Buffer: BufferConfig{Size: 3}},
+			WithAppName("buffer-test"), WithCustomOutput("buffer-test-entries", &fakeSink{Writer: io.Discard}))
+		require.NoError(t, err)
+
+		buffer := log.Buffer()
+		require.NotNil(t, buffer)
+		require.Equal(t, buffer, log.Named("worker").Buffer())
+
+		log.Debug("should be skipped by level")
+		log.Infow("first", "password", "secret")
+		require.Len(t, buffer.Entries(zapcore.DebugLevel, time.Time{}), 1)
+
+		entry := buffer.Entries(zapcore.DebugLevel, time.Time{})[0]
+		require.Equal(t, "first", entry.Message)
+		require.Equal(t, zapcore.InfoLevel, entry.Level)
+		require.Contains(t, entry.Caller, "logger/buffer_test.go")
+		require.Equal(t, map[string]interface{}{"app": "buffer-test", "password": RedactedValue}, entry.Fields)
+
+		log.Warn("second")
+		log.Named("worker").Error("third")
+		log.Info("fourth")
+
+		entries := buffer.Entries(zapcore.DebugLevel, time.Time{})
+		require.Len(t, entries, 3)
+		require.Equal(t, []string{"second", "third", "fourth"}, []string{entries[0].Message, entries[1].Message, entries[2].Message})
+		require.Equal(t, "worker", entries[1].Logger)
+
+		entries = buffer.Entries(zapcore.WarnLevel, time.Time{})
+		require.Len(t, entries, 2)
+
+		require.Empty(t, buffer.Entries(zapcore.DebugLevel, time.Now().Add(time.Minute)))
+	})
+
+	t.Run("should notify subscribers", func(t *testing.T) {
+		buffer := newBuffer(2)
+		core := newBufferCore(buffer)
+		require.NoError(t, core.Write(zapcore.Entry{Message: "kept"}, nil))
+
+		entries, stream, cancel := buffer.Subscribe()
+		require.Len(t, entries, 1)
+
+		require.NoError(t, core.With([]zapcore.Field{zap.Int("user", 42)}).
+			Write(zapcore.Entry{Message: "new"}, nil))
+
+		entry := <-stream
+		require.Equal(t, "new", entry.Message)
+		require.Equal(t, map[string]interface{}{"user": int64(42)}, entry.Fields)
+
+		cancel()
+		cancel()
+
+		_, ok := <-stream
+		require.False(t, ok)
+
+		// should not block without subscribers
+		require.NoError(t, core.Write(zapcore.Entry{Message: "skipped"}, nil))
+		require.Len(t, buffer.Entries(zapcore.DebugLevel, time.Time{}), 2)
+	})
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// defaultLogger is used when context does not contain logger, it is created once on first use.
+// nolint: gochecknoglobals
+var defaultLogger = sync.OnceValue(Default)
+
+const (
+	// TraceIDKey is a field name of trace identifier.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is a field name of span identifier.
+	SpanIDKey = "span_id"
+	// TraceSampledKey is a field name of trace sampled flag.
+	TraceSampledKey = "trace_sampled"
+)
+
+// WithContext returns copy of context that contains passed Logger.
+func WithContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns Logger stored in context by WithContext or Default logger.
+func FromContext(ctx context.Context) Logger {
+	if log, ok := ctx.Value(contextKey{}).(Logger); ok && log != nil {
+		return log
+	}
+
+	return defaultLogger()
+}
+
+// WithTraceContext returns copy of context that contains passed Logger with trace fields
+// of the OpenTelemetry span stored in context, so request-scoped logs could be correlated with traces.
+// Ctx-methods (e.g. InfowCtx) of that logger do not duplicate trace fields of the same span.
+func WithTraceContext(ctx context.Context, log Logger) context.Context {
+	fields := TraceFields(ctx)
+	if len(fields) == 0 {
+		return WithContext(ctx, log)
+	}
+
+	l, ok := log.(*logger)
+	if !ok {
+		return WithContext(ctx, log.With(fields...))
+	}
+
+	child := l.child(l.SugaredLogger.With(fields...))
+	child.span = trace.SpanContextFromContext(ctx).SpanID()
+
+	return WithContext(ctx, child)
+}
+
+// TraceFields returns trace_id, span_id and trace_sampled key-value pairs
+// of the OpenTelemetry span stored in context, or nothing when span is not valid.
+func TraceFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	span := trace.SpanContextFromContext(ctx)
+	if !span.IsValid() {
+		return nil
+	}
+
+	return []interface{}{
+		TraceIDKey, span.TraceID().String(),
+		SpanIDKey, span.SpanID().String(),
+		TraceSampledKey, span.IsSampled(),
+	}
+}
+
+// withContext returns logger that skips ctx-method frame and key-value pairs with trace fields.
+func (l *logger) withContext(ctx context.Context, keysAndValues []interface{}) (*SugaredLogger, []interface{}) {
+	log := l.SugaredLogger.WithOptions(zap.AddCallerSkip(1))
+	if ctx != nil && l.span.IsValid() && l.span == trace.SpanContextFromContext(ctx).SpanID() {
+		return log, keysAndValues
+	}
+
+	return log, append(TraceFields(ctx), keysAndValues...)
+}
+
+// DebugwCtx logs a message with trace fields from context and some additional context.
+func (l *logger) DebugwCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Debugw(msg, args...)
+}
+
+// InfowCtx logs a message with trace fields from context and some additional context.
+func (l *logger) InfowCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Infow(msg, args...)
+}
+
+// WarnwCtx logs a message with trace fields from context and some additional context.
+func (l *logger) WarnwCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Warnw(msg, args...)
+}
+
+// ErrorwCtx logs a message with trace fields from context and some additional context.
+func (l *logger) ErrorwCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Errorw(msg, args...)
+}
+
+// FatalwCtx logs a message with trace fields from context and some additional context, then calls os.Exit.
+func (l *logger) FatalwCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Fatalw(msg, args...)
+}
+
+// PanicwCtx logs a message with trace fields from context and some additional context, then panics.
+func (l *logger) PanicwCtx(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	log, args := l.withContext(ctx, keysAndValues)
+	log.Panicw(msg, args...)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContext(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := &logger{SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+
+	t.Run("should return logger from context", func(t *testing.T) {
+		ctx := WithContext(context.Background(), log)
+		require.Equal(t, log, FromContext(ctx))
+		require.NotNil(t, FromContext(context.Background()))
+		require.Same(t, FromContext(context.Background()), FromContext(context.Background()))
+	})
+
+	t.Run("should skip trace fields without span", func(t *testing.T) {
+		require.Empty(t, TraceFields(context.Background()))
+
+		log.InfowCtx(context.Background(), "without span", "key", "val")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, map[string]interface{}{"key": "val"}, entries[0].ContextMap())
+	})
+
+	t.Run("should add trace fields", func(t *testing.T) {
+		span := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceFlags: trace.FlagsSampled,
+		})
+
+		ctx := trace.ContextWithSpanContext(context.Background(), span)
+
+		methods := map[zapcore.Level]func(context.Context, string, ...interface{}){
+			zapcore.DebugLevel: log.DebugwCtx,
+			zapcore.InfoLevel:  log.InfowCtx,
+			zapcore.WarnLevel:  log.WarnwCtx,
+			zapcore.ErrorLevel: log.ErrorwCtx,
+		}
+
+		for level, method := range methods {
+			method(ctx, "with span", "key", "val")
+
+			entries := logs.TakeAll()
+			require.Len(t, entries, 1)
+			require.Equal(t, level, entries[0].Level)
+			require.Equal(t, "context_test.go", filepath.Base(entries[0].Caller.File))
+			require.Equal(t, map[string]interface{}{
+				"key":           "val",
+				TraceIDKey:      "0102030405060708090a0b0c0d0e0f10",
+				SpanIDKey:       "0102030405060708",
+				TraceSampledKey: true,
+			}, entries[0].ContextMap())
+		}
+
+		require.Panics(t, func() { log.PanicwCtx(ctx, "panic") })
+		require.Len(t, logs.TakeAll(), 1)
+	})
+
+	t.Run("should store logger with trace fields", func(t *testing.T) {
+		require.Equal(t, log, FromContext(WithTraceContext(context.Background(), log)))
+
+		span := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		})
+
+		ctx := trace.ContextWithSpanContext(context.Background(), span)
+		ctx = WithTraceContext(ctx, log)
+
+		FromContext(ctx).Infow("plain", "key", "val")
+		FromContext(ctx).InfowCtx(ctx, "with span", "key", "val")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+
+		for _, entry := range entries {
+			require.Len(t, entry.Context, 4) // trace fields should not be duplicated
+			require.Equal(t, map[string]interface{}{
+				"key":           "val",
+				TraceIDKey:      "0102030405060708090a0b0c0d0e0f10",
+				SpanIDKey:       "0102030405060708",
+				TraceSampledKey: false,
+			}, entry.ContextMap())
+		}
+	})
+}
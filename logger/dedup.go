@@ -0,0 +1,354 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DedupConfig provides configuration of repeated messages deduplication.
+type DedupConfig struct {
+	Window time.Duration `env:"WINDOW" default:"0s" usage:"allows to collapse repeated messages in window (0 disables)"`
+	Keys   string        `env:"KEYS" usage:"allows to set fields, that distinguish repeated messages" example:"error,user_id"`
+}
+
+type (
+	// deduplicator collects repeated entries (same level, logger name, message and selected fields) in window,
+	// the first entry is written immediately, the rest of them are collapsed into one entry with `repeated` field.
+	// When deduplicator was stopped, entries are written as is.
+	deduplicator struct {
+		mu      sync.Mutex
+		stopped bool
+		window  time.Duration
+		keys    map[string]struct{}
+		entries map[string]*dedupEntry
+		errs    zapcore.WriteSyncer // error output of the logger
+
+		quit chan struct{}
+		done chan struct{}
+	}
+
+	dedupEntry struct {
+		core   zapcore.Core
+		ent    zapcore.Entry
+		fields []zapcore.Field
+		count  int
+		start  time.Time
+	}
+
+	// dedupCore skips repeated entries, it should wrap core that writes entries (and sampler, so all entries are counted),
+	// entries are written through Check of the wrapped core, so levels of teed cores are respected.
+	dedupCore struct {
+		zapcore.Core
+
+		dedup   *deduplicator
+		context []string // selected context fields in `key=value` format
+	}
+
+	// everyCore allows to write entry once in interval per call site, so count of keys is limited by code size.
+	everyCore struct {
+		zapcore.Core
+
+		every time.Duration
+		last  *sync.Map // everyKey -> *atomic.Int64 (unix nano time of the last written entry)
+	}
+
+	everyKey struct {
+		site  uintptr
+		every time.Duration
+	}
+)
+
+// RepeatedKey is a field name of count of collapsed repeated entries.
+const RepeatedKey = "repeated"
+
+// nolint: gochecknoglobals
+var callSites sync.Map // uintptr -> bool, whether program counter belongs to zap or logger packages
+
+// Validate checks that deduplication window is not negative.
+func (c DedupConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Window, validation.Min(time.Duration(0))))
+}
+
+// newDeduplicator returns nil when window is not passed, it flushes collapsed entries every window in background.
+func newDeduplicator(cfg DedupConfig, errs zapcore.WriteSyncer) *deduplicator {
+	if cfg.Window <= 0 {
+		return nil
+	}
+
+	out := &deduplicator{
+		window:  cfg.Window,
+		keys:    make(map[string]struct{}),
+		entries: make(map[string]*dedupEntry),
+		errs:    errs,
+
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	for _, key := range strings.Split(cfg.Keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			out.keys[key] = struct{}{}
+		}
+	}
+
+	go out.run()
+
+	return out
+}
+
+// run writes collapsed entries of expired windows until deduplicator is stopped.
+func (d *deduplicator) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.quit:
+			return
+		case now := <-ticker.C:
+			if err := d.flush(now, false); err != nil {
+				reportError(d.errs, "could not write repeated logs: %s", err)
+			}
+		}
+	}
+}
+
+// stop stops background goroutine and writes all collapsed entries, next entries are written as is.
+func (d *deduplicator) stop() error {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+
+		return nil
+	}
+
+	d.stopped = true
+	d.mu.Unlock()
+
+	close(d.quit)
+	<-d.done
+
+	return d.flush(time.Now(), true)
+}
+
+// wrapCore wraps core with deduplication, it returns core as is, when deduplicator is nil.
+func (d *deduplicator) wrapCore(core zapcore.Core) zapcore.Core {
+	if d == nil {
+		return core
+	}
+
+	return &dedupCore{Core: core, dedup: d}
+}
+
+// selected returns selected fields in `key=value` format.
+func (d *deduplicator) selected(fields []zapcore.Field) []string {
+	if len(d.keys) == 0 {
+		return nil
+	}
+
+	var out []string
+	for _, field := range fields {
+		if _, ok := d.keys[field.Key]; !ok {
+			continue
+		}
+
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+
+		out = append(out, fmt.Sprintf("%s=%v", field.Key, enc.Fields[field.Key]))
+	}
+
+	return out
+}
+
+// flush writes collapsed entries of expired windows (or all of them) and forgets them.
+func (d *deduplicator) flush(now time.Time, all bool) error {
+	var repeated []*dedupEntry
+
+	d.mu.Lock()
+	for key, entry := range d.entries {
+		if !all && now.Sub(entry.start) < d.window {
+			continue
+		}
+
+		if entry.count > 0 {
+			repeated = append(repeated, entry)
+		}
+
+		delete(d.entries, key)
+	}
+	d.mu.Unlock()
+
+	var err error
+	for _, entry := range repeated {
+		err = errors.Join(err, entry.write())
+	}
+
+	return err
+}
+
+// write writes the last repeated entry with count of collapsed entries.
+func (e *dedupEntry) write() error {
+	return checkedWrite(e.core, e.ent, append(e.fields, zap.Int(RepeatedKey, e.count)))
+}
+
+// With adds structured context to the wrapped core.
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{
+		Core:    c.Core.With(fields),
+		dedup:   c.dedup,
+		context: append(append([]string{}, c.context...), c.dedup.selected(fields)...),
+	}
+}
+
+// Check adds core to the checked entry, when level is enabled.
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write writes the first entry in window and collapses the rest of them,
+// collapsed entries of the previous window are written before the new one.
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	selected := append(c.dedup.selected(fields), c.context...)
+	sort.Strings(selected)
+
+	key := strings.Join(append([]string{ent.Level.String(), ent.LoggerName, ent.Message}, selected...), "\x00")
+
+	c.dedup.mu.Lock()
+	if c.dedup.stopped {
+		c.dedup.mu.Unlock()
+
+		return checkedWrite(c.Core, ent, fields)
+	}
+
+	entry, ok := c.dedup.entries[key]
+	if ok && ent.Time.Sub(entry.start) < c.dedup.window {
+		entry.core, entry.ent, entry.fields = c.Core, ent, append([]zapcore.Field{}, fields...)
+		entry.count++
+		c.dedup.mu.Unlock()
+
+		return nil
+	}
+
+	c.dedup.entries[key] = &dedupEntry{core: c.Core, ent: ent, start: ent.Time}
+	c.dedup.mu.Unlock()
+
+	var err error
+	if ok && entry.count > 0 {
+		err = entry.write()
+	}
+
+	return errors.Join(err, checkedWrite(c.Core, ent, fields))
+}
+
+// Sync writes all collapsed entries and syncs the wrapped core.
+func (c *dedupCore) Sync() error {
+	return errors.Join(c.dedup.flush(time.Now(), true), c.Core.Sync())
+}
+
+// Every returns logger, that writes entry at most once per interval for each call site,
+// e.g. `log.Every(time.Minute).Warnw("queue is full")` in hot path.
+// Intervals are shared by the logger and its children (With, Named).
+func (l *logger) Every(interval time.Duration) Logger {
+	if interval <= 0 || l.every == nil {
+		return l
+	}
+
+	return l.child(l.SugaredLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &everyCore{Core: core, every: interval, last: l.every}
+	})))
+}
+
+// With adds structured context to the wrapped core.
+func (c *everyCore) With(fields []zapcore.Field) zapcore.Core {
+	return &everyCore{Core: c.Core.With(fields), every: c.every, last: c.last}
+}
+
+// Check passes entry to the wrapped core, when entry was not written in the interval.
+func (c *everyCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	value, _ := c.last.LoadOrStore(everyKey{site: callSite(), every: c.every}, new(atomic.Int64))
+	last, ok := value.(*atomic.Int64)
+
+	now := ent.Time.UnixNano()
+	for ok {
+		prev := last.Load()
+		if prev != 0 && now-prev < int64(c.every) {
+			return ce
+		}
+
+		if last.CompareAndSwap(prev, now) {
+			break
+		}
+	}
+
+	return c.Core.Check(ent, ce)
+}
+
+// callSite returns program counter of the first frame outside of zap and logger packages,
+// frames are resolved once per program counter, so stack is not symbolized on every entry.
+func callSite() uintptr {
+	const maxDepth = 32
+
+	var pcs [maxDepth]uintptr
+
+	count := runtime.Callers(1, pcs[:])
+	for _, pc := range pcs[:count] {
+		if !internalPC(pc) {
+			return pc
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return pcs[count-1]
+}
+
+// internalPC reports whether all frames of program counter (including inlined) belong to zap or logger packages.
+func internalPC(pc uintptr) bool {
+	if cached, ok := callSites.Load(pc); ok {
+		out, _ := cached.(bool)
+
+		return out
+	}
+
+	out := true
+	frames := runtime.CallersFrames([]uintptr{pc})
+
+	for more := true; more; {
+		var frame runtime.Frame
+
+		frame, more = frames.Next()
+		out = out && internalFrame(frame)
+	}
+
+	callSites.Store(pc, out)
+
+	return out
+}
@@ -0,0 +1,210 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+func TestDedupConfig_Validate(t *testing.T) {
+	require.NoError(t, DedupConfig{}.Validate())
+	require.NoError(t, DedupConfig{Window: time.Minute, Keys: "error"}.Validate())
+	require.EqualError(t, DedupConfig{Window: -time.Second}.Validate(), "Window: must be no less than 0s.")
+}
+
+func TestDedupCore(t *testing.T) {
+	t.Run("should not wrap core when window is not passed", func(t *testing.T) {
+		core, _ := observer.New(zapcore.DebugLevel)
+		require.Nil(t, newDeduplicator(DedupConfig{}, nil))
+		require.Equal(t, core, newDeduplicator(DedupConfig{}, nil).wrapCore(core))
+	})
+
+	t.Run("should collapse repeated entries", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		clock := &testClock{now: time.Now()}
+		log := zap.New(newDeduplicator(DedupConfig{Window: time.Hour, Keys: "user"}, nil).wrapCore(core), zap.WithClock(clock)).Sugar()
+
+		for i := 0; i < 3; i++ {
+			log.Warnw("repeated", "user", 1, "attempt", i)
+		}
+
+		log.Warnw("repeated", "user", 2)
+		log.With("user", 3).Warnw("repeated")
+		log.With("user", 3).Warnw("repeated")
+		log.Infow("repeated", "user", 1)
+		log.Named("worker").Warnw("repeated", "user", 1)
+
+		require.Equal(t, 5, logs.Len())
+		require.Empty(t, logs.FilterFieldKey(RepeatedKey).All())
+
+		require.NoError(t, log.Sync())
+
+		repeated := logs.FilterFieldKey(RepeatedKey).All()
+		require.Len(t, repeated, 2)
+
+		for _, entry := range repeated {
+			switch entry.ContextMap()["user"] {
+			case int64(1):
+				// the last entry is written with count of collapsed entries
+				require.Equal(t, map[string]interface{}{"user": int64(1), "attempt": int64(2), RepeatedKey: int64(2)}, entry.ContextMap())
+			case int64(3):
+				require.Equal(t, map[string]interface{}{"user": int64(3), RepeatedKey: int64(1)}, entry.ContextMap())
+			default:
+				t.Fatalf("unexpected entry %v", entry.ContextMap())
+			}
+		}
+
+		// window is reset after sync
+		log.Warnw("repeated", "user", 1)
+		require.Equal(t, 8, logs.Len())
+	})
+
+	t.Run("should write collapsed entries when window expired", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		clock := &testClock{now: time.Now()}
+		log := zap.New(newDeduplicator(DedupConfig{Window: time.Hour}, nil).wrapCore(core), zap.WithClock(clock)).Sugar()
+
+		log.Warn("repeated")
+		log.Warn("repeated")
+		log.Warn("repeated")
+
+		clock.now = clock.now.Add(time.Hour)
+		log.Warn("repeated")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 3)
+		require.Empty(t, entries[0].ContextMap())
+		require.Equal(t, map[string]interface{}{RepeatedKey: int64(2)}, entries[1].ContextMap())
+		require.Empty(t, entries[2].ContextMap())
+	})
+
+	t.Run("should write collapsed entries in background", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := zap.New(newDeduplicator(DedupConfig{Window: time.Millisecond * 10}, nil).wrapCore(core)).Sugar()
+
+		log.Warn("repeated")
+		log.Warn("repeated")
+
+		require.Eventually(t, func() bool {
+			return logs.FilterFieldKey(RepeatedKey).Len() == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should collapse entries of logger", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		log, err := New(Config{Level: "info", Trace: "fatal", Dedup: DedupConfig{Window: time.Hour}},
+			WithCustomOutput("dedup-test", &fakeSink{Writer: out}))
+		require.NoError(t, err)
+
+		for i := 0; i < 5; i++ {
+			log.Errorw("could not connect", "attempt", i)
+		}
+
+		require.NoError(t, log.Sync())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		require.Contains(t, lines[0], `"attempt":0`)
+		require.Contains(t, lines[1], `"attempt":4,"repeated":4`)
+	})
+
+	t.Run("should count entries before sampling", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		rate := 5
+		log, err := New(Config{Level: "info", Trace: "fatal", SampleRate: &rate, Dedup: DedupConfig{Window: time.Hour}},
+			WithCustomOutput("dedup-sampled-test", &fakeSink{Writer: out}))
+		require.NoError(t, err)
+
+		for i := 0; i < 20; i++ {
+			log.Errorw("could not connect", "attempt", i)
+		}
+
+		require.NoError(t, log.Sync())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		require.Contains(t, lines[1], `"attempt":19,"repeated":19`)
+	})
+
+	t.Run("should respect levels of teed cores", func(t *testing.T) {
+		debug, debugLogs := observer.New(zapcore.DebugLevel)
+		errs, errorLogs := observer.New(zapcore.ErrorLevel)
+		log := zap.New(newDeduplicator(DedupConfig{Window: time.Hour}, nil).wrapCore(zapcore.NewTee(debug, errs))).Sugar()
+
+		log.Info("repeated")
+		log.Info("repeated")
+		log.Error("repeated")
+
+		require.NoError(t, log.Sync())
+		require.Equal(t, 3, debugLogs.Len())
+		require.Equal(t, 1, errorLogs.Len())
+		require.Equal(t, 1, debugLogs.FilterFieldKey(RepeatedKey).Len())
+	})
+
+	t.Run("should write entries as is after stop", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		dedup := newDeduplicator(DedupConfig{Window: time.Hour}, nil)
+		log := zap.New(dedup.wrapCore(core)).Sugar()
+
+		log.Warn("repeated")
+		log.Warn("repeated")
+
+		require.NoError(t, dedup.stop())
+		require.NoError(t, dedup.stop())
+		require.Equal(t, 1, logs.FilterFieldKey(RepeatedKey).Len())
+
+		select {
+		case <-dedup.done:
+		default:
+			require.Fail(t, "deduplicator should be stopped")
+		}
+
+		log.Warn("repeated")
+		log.Warn("repeated")
+		require.Equal(t, 4, logs.Len())
+	})
+}
+
+func TestEvery(t *testing.T) {
+	log := ForTestsObserved(t)
+
+	require.Equal(t, log.Logger, log.Every(0))
+
+	for i := 0; i < 3; i++ {
+		log.Every(time.Hour).Warnw("hot path", "attempt", i)
+		log.Every(time.Hour).Named("worker").Infof("hot path %d", 1)
+	}
+
+	log.Every(time.Hour).Warnw("hot path", "attempt", 3)
+	log.Warnw("hot path", "attempt", 4)
+
+	// entries are limited per call site
+	entries := log.FilterMessage("hot path")
+	require.Len(t, entries, 3)
+	require.Equal(t, int64(0), entries[0].ContextMap()["attempt"])
+	require.Equal(t, int64(3), entries[1].ContextMap()["attempt"])
+	require.Equal(t, int64(4), entries[2].ContextMap()["attempt"])
+
+	require.Len(t, log.FilterMessage("hot path 1"), 1)
+
+	// entries are limited per call site, formatted messages do not create new keys
+	for i := 0; i < 3; i++ {
+		log.Every(time.Hour).Warnf("attempt %d", i)
+	}
+
+	require.Len(t, log.FilterMessage("attempt 0"), 1)
+	require.Empty(t, log.FilterMessage("attempt 1"))
+	require.Empty(t, log.FilterMessage("attempt 2"))
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// EncoderConfig allows to change keys and formatting of log entries, so logs could match collector schema.
+// Empty keys are replaced with default values, `-` omits the key.
+type EncoderConfig struct {
+	MessageKey    string `env:"MESSAGE_KEY" default:"msg" usage:"allows to set message key (- omits it)"`
+	LevelKey      string `env:"LEVEL_KEY" default:"level" usage:"allows to set level key (- omits it)"`
+	TimeKey       string `env:"TIME_KEY" default:"ts" usage:"allows to set time key (- omits it)"`
+	NameKey       string `env:"NAME_KEY" default:"logger" usage:"allows to set logger name key (- omits it)"`
+	CallerKey     string `env:"CALLER_KEY" default:"caller" usage:"allows to set caller key (- omits it)"`
+	StacktraceKey string `env:"STACKTRACE_KEY" default:"stacktrace" usage:"allows to set stacktrace key (- omits it)"`
+	TimeFormat    string `env:"TIME_FORMAT" default:"iso8601" enum:"iso8601,rfc3339nano,epoch,millis" usage:"allows to set time format"`
+	LevelCase     string `env:"LEVEL_CASE" default:"lower" enum:"lower,upper" usage:"allows to set level case"`
+	DisableCaller bool   `env:"DISABLE_CALLER" default:"false" usage:"allows to disable caller"`
+}
+
+const (
+	// EncodingJSON writes entries as JSON objects.
+	EncodingJSON = "json"
+	// EncodingConsole writes entries in user-friendly format.
+	EncodingConsole = "console"
+	// EncodingLogfmt writes entries as logfmt `key=value` pairs.
+	EncodingLogfmt = "logfmt"
+
+	omitKey = "-"
+)
+
+// encoding returns zap encoding name, EncodingConsole flag overrides encoding for backward compatibility.
+func (c Config) encoding() string {
+	switch {
+	case c.EncodingConsole:
+		return EncodingConsole
+	case c.Encoding == EncodingConsole, c.Encoding == EncodingLogfmt:
+		return c.Encoding
+	default:
+		return EncodingJSON
+	}
+}
+
+// apply changes keys and encoders of zapcore.EncoderConfig.
+func (c EncoderConfig) apply(out *zapcore.EncoderConfig) {
+	setKey := func(key *string, value string) {
+		switch value {
+		case "":
+		case omitKey:
+			*key = zapcore.OmitKey
+		default:
+			*key = value
+		}
+	}
+
+	setKey(&out.MessageKey, c.MessageKey)
+	setKey(&out.LevelKey, c.LevelKey)
+	setKey(&out.TimeKey, c.TimeKey)
+	setKey(&out.NameKey, c.NameKey)
+	setKey(&out.CallerKey, c.CallerKey)
+	setKey(&out.StacktraceKey, c.StacktraceKey)
+
+	out.EncodeTime = timeEncoder(c.TimeFormat)
+
+	out.EncodeLevel = zapcore.LowercaseLevelEncoder
+	if strings.EqualFold(c.LevelCase, "upper") {
+		out.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+}
+
+// newEncoder returns encoder by encoding name, it is used by cores, that are not built by zap.Config.
+func newEncoder(encoding string, cfg zapcore.EncoderConfig) zapcore.Encoder {
+	switch encoding {
+	case EncodingConsole:
+		return zapcore.NewConsoleEncoder(cfg)
+	case EncodingLogfmt:
+		return NewLogfmtEncoder(cfg)
+	default:
+		return zapcore.NewJSONEncoder(cfg)
+	}
+}
+
+// timeEncoder returns zapcore.TimeEncoder by format name, ISO8601 is used by default.
+func timeEncoder(format string) zapcore.TimeEncoder {
+	switch strings.ToLower(format) {
+	case "rfc3339nano":
+		return zapcore.RFC3339NanoTimeEncoder
+	case "epoch":
+		return zapcore.EpochTimeEncoder
+	case "millis":
+		return zapcore.EpochMillisTimeEncoder
+	default:
+		return zapcore.ISO8601TimeEncoder
+	}
+}
@@ -0,0 +1,362 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.uber.org/zap/zapcore"
+)
+
+// FileConfig provides configuration of file output with rotation.
+type FileConfig struct {
+	Path           string        `env:"PATH" usage:"allows to write logs into file instead of stderr" example:"/var/log/app.log"`
+	MaxSize        int           `env:"MAX_SIZE" default:"100" usage:"allows to set max log file size in megabytes (0 disables rotation)"`
+	MaxAge         time.Duration `env:"MAX_AGE" default:"0s" usage:"allows to set max age of rotated files (0 keeps all)"`
+	MaxBackups     int           `env:"MAX_BACKUPS" default:"0" usage:"allows to set max count of rotated files (0 keeps all)"`
+	Compress       bool          `env:"COMPRESS" default:"false" usage:"allows to compress rotated files with gzip"`
+	RotateInterval time.Duration `env:"ROTATE_INTERVAL" default:"0s" usage:"allows to rotate log file by interval (0 disables)"`
+}
+
+// fileSink implements Sink that writes into file and rotates it by size or interval.
+// When rotation could not reopen the file, file is nil and next write tries to open it again.
+type fileSink struct {
+	mu sync.Mutex
+	wg sync.WaitGroup
+
+	cfg     FileConfig
+	errs    zapcore.WriteSyncer // error output of the logger
+	limit   int64
+	file    *os.File
+	size    int64
+	opened  time.Time
+	closed  bool
+	cleanup chan struct{} // notifies single cleanup worker, that is started on first rotation
+	now     func() time.Time
+}
+
+const (
+	megabyte = 1024 * 1024
+
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+)
+
+var _ Sink = (*fileSink)(nil)
+
+// Validate checks that rotation settings are not negative.
+func (c FileConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.MaxSize, validation.Min(0)),
+		validation.Field(&c.MaxAge, validation.Min(time.Duration(0))),
+		validation.Field(&c.MaxBackups, validation.Min(0)),
+		validation.Field(&c.RotateInterval, validation.Min(time.Duration(0))))
+}
+
+func newFileSink(cfg FileConfig, errs zapcore.WriteSyncer) (*fileSink, error) {
+	sink := &fileSink{cfg: cfg, errs: errs, limit: int64(cfg.MaxSize) * megabyte, now: time.Now}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+func (s *fileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.cfg.Path), 0o755); err != nil {
+		return fmt.Errorf("could not create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(s.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	s.file, s.size, s.opened = file, info.Size(), s.now()
+
+	return nil
+}
+
+// Write writes data into file, rotates it when size limit or rotate interval reached.
+// When rotation fails, data is written into current file and rotation error is returned.
+func (s *fileSink) Write(data []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, os.ErrClosed
+	}
+
+	var errRotate error
+	if s.file == nil {
+		errRotate = s.open()
+	} else if s.shouldRotate(len(data)) {
+		errRotate = s.rotate()
+	}
+
+	if s.file == nil {
+		return 0, errRotate
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+
+	return n, errors.Join(errRotate, err)
+}
+
+func (s *fileSink) shouldRotate(size int) bool {
+	if s.size == 0 {
+		return false
+	}
+
+	if s.limit > 0 && s.size+int64(size) > s.limit {
+		return true
+	}
+
+	return s.cfg.RotateInterval > 0 && s.now().Sub(s.opened) >= s.cfg.RotateInterval
+}
+
+// rotate renames current file into backup, opens new one and notifies cleanup worker.
+// When rename fails, original file is reopened, when reopen fails, file is left nil.
+func (s *fileSink) rotate() error {
+	err := s.file.Close()
+	if s.file = nil; err != nil {
+		return err
+	}
+
+	if err = os.Rename(s.cfg.Path, s.backupName(s.now())); err != nil {
+		return errors.Join(fmt.Errorf("could not rotate log file: %w", err), s.open())
+	}
+
+	if err = s.open(); err != nil {
+		return err
+	}
+
+	if s.cleanup == nil {
+		s.cleanup = make(chan struct{}, 1)
+
+		s.wg.Add(1)
+		go s.cleanupWorker()
+	}
+
+	select {
+	case s.cleanup <- struct{}{}:
+	default: // cleanup is already pending
+	}
+
+	return nil
+}
+
+// backupName returns unique name of backup, index is added when backup with the same time already exists.
+func (s *fileSink) backupName(now time.Time) string {
+	ext := filepath.Ext(s.cfg.Path)
+	name := strings.TrimSuffix(s.cfg.Path, ext) + "-" + now.UTC().Format(backupTimeFormat)
+
+	out := name + ext
+	for idx := 1; fileExists(out) || fileExists(out+compressSuffix); idx++ {
+		out = name + "-" + strconv.Itoa(idx) + ext
+	}
+
+	return out
+}
+
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+
+	return err == nil
+}
+
+// cleanupWorker serializes cleanups until sink will be closed.
+func (s *fileSink) cleanupWorker() {
+	defer s.wg.Done()
+
+	for range s.cleanup {
+		s.cleanupBackups()
+	}
+}
+
+// cleanupBackups compresses rotated files and removes backups that exceed max backups or max age.
+func (s *fileSink) cleanupBackups() {
+	backups := s.backups()
+
+	if s.cfg.Compress {
+		for i, item := range backups {
+			if strings.HasSuffix(item.path, compressSuffix) {
+				continue
+			}
+
+			if err := compressFile(item.path); err != nil {
+				reportError(s.errs, "could not compress log file %s: %s", item.path, err)
+
+				continue
+			}
+
+			backups[i].path += compressSuffix
+		}
+	}
+
+	if s.cfg.MaxBackups <= 0 && s.cfg.MaxAge <= 0 {
+		return
+	}
+
+	for i, item := range backups {
+		if (s.cfg.MaxBackups > 0 && i >= s.cfg.MaxBackups) ||
+			(s.cfg.MaxAge > 0 && s.now().Sub(item.time) > s.cfg.MaxAge) {
+			_ = os.Remove(item.path)
+		}
+	}
+}
+
+type backupFile struct {
+	path  string
+	time  time.Time
+	index int
+}
+
+// backups returns rotated files sorted by time, the newest first.
+func (s *fileSink) backups() []backupFile {
+	dir := filepath.Dir(s.cfg.Path)
+	ext := filepath.Ext(s.cfg.Path)
+	prefix := strings.TrimSuffix(filepath.Base(s.cfg.Path), ext) + "-"
+
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var out []backupFile
+	for _, item := range list {
+		name := strings.TrimSuffix(item.Name(), compressSuffix)
+		if item.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+
+		when, errParse := time.Parse(backupTimeFormat, stamp[:len(backupTimeFormat)])
+		if errParse != nil {
+			continue
+		}
+
+		// index of backups rotated at the same time (see backupName)
+		var index int
+		if suffix := stamp[len(backupTimeFormat):]; suffix != "" {
+			if index, errParse = strconv.Atoi(strings.TrimPrefix(suffix, "-")); errParse != nil || !strings.HasPrefix(suffix, "-") {
+				continue
+			}
+		}
+
+		out = append(out, backupFile{path: filepath.Join(dir, item.Name()), time: when, index: index})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].time.Equal(out[j].time) {
+			return out[i].index > out[j].index
+		}
+
+		return out[i].time.After(out[j].time)
+	})
+
+	return out
+}
+
+func compressFile(name string) (err error) {
+	src, err := os.Open(name) // nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644) // nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+
+	if errClose := dst.Close(); err == nil {
+		err = errClose
+	}
+
+	if err != nil {
+		return errors.Join(err, os.Remove(name+compressSuffix))
+	}
+
+	return os.Remove(name)
+}
+
+// Reopen closes and opens log file again, should be used when file was moved by logrotate.
+func (s *fileSink) Reopen() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return os.ErrClosed
+	}
+
+	if s.file != nil {
+		err := s.file.Close()
+		if s.file = nil; err != nil {
+			return err
+		}
+	}
+
+	return s.open()
+}
+
+// Sync flushes file to the disk.
+func (s *fileSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	return s.file.Sync()
+}
+
+// Close closes file, stops cleanup worker and waits until pending cleanup is done.
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+
+	var err error
+	if s.file != nil {
+		err = s.file.Close()
+		s.file = nil
+	}
+
+	if !s.closed && s.cleanup != nil {
+		close(s.cleanup)
+	}
+
+	s.closed = true
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return err
+}
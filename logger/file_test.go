@@ -0,0 +1,217 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Add(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestFileSink(t *testing.T, cfg FileConfig, limit int64) (*fileSink, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)}
+
+	sink := &fileSink{cfg: cfg, limit: limit, now: clock.Now}
+	require.NoError(t, sink.open())
+
+	t.Cleanup(func() { require.NoError(t, sink.Close()) })
+
+	return sink, clock
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	list, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		out = append(out, item.Name())
+	}
+
+	return out
+}
+
+func TestFileSink(t *testing.T) {
+	t.Run("should rotate by size and keep max backups", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, clock := newTestFileSink(t, FileConfig{Path: filepath.Join(dir, "nested", "app.log"), MaxBackups: 2}, 10)
+
+		for i := 0; i < 4; i++ {
+			_, err := sink.Write([]byte("0123456789"))
+			require.NoError(t, err)
+
+			clock.Add(time.Second)
+		}
+
+		require.NoError(t, sink.Close())
+		require.Equal(t, []string{
+			"app-2023-01-01T10-00-02.000.log",
+			"app-2023-01-01T10-00-03.000.log",
+			"app.log",
+		}, listFiles(t, filepath.Join(dir, "nested")))
+	})
+
+	t.Run("should rotate by interval and compress", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, clock := newTestFileSink(t, FileConfig{
+			Path:           filepath.Join(dir, "app.log"),
+			Compress:       true,
+			RotateInterval: time.Hour,
+		}, 0)
+
+		_, err := sink.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		clock.Add(time.Hour)
+
+		_, err = sink.Write([]byte("second\n"))
+		require.NoError(t, err)
+		require.NoError(t, sink.Close())
+
+		require.Equal(t, []string{"app-2023-01-01T11-00-00.000.log.gz", "app.log"}, listFiles(t, dir))
+
+		file, err := os.Open(filepath.Join(dir, "app-2023-01-01T11-00-00.000.log.gz"))
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, file.Close()) }()
+
+		gz, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		require.Equal(t, "first\n", string(data))
+	})
+
+	t.Run("should remove old backups", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, _ := newTestFileSink(t, FileConfig{Path: filepath.Join(dir, "app.log"), MaxAge: time.Minute}, 1)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "app-2022-01-01T10-00-00.000.log"), nil, 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "app-unknown.log"), nil, 0o600))
+
+		_, err := sink.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		_, err = sink.Write([]byte("second\n"))
+		require.NoError(t, err)
+		require.NoError(t, sink.Close())
+
+		require.Equal(t, []string{
+			"app-2023-01-01T10-00-00.000.log",
+			"app-unknown.log",
+			"app.log",
+		}, listFiles(t, dir))
+	})
+
+	t.Run("should not overwrite backups rotated at the same time", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, _ := newTestFileSink(t, FileConfig{Path: filepath.Join(dir, "app.log"), MaxBackups: 2}, 1)
+
+		for i := 0; i < 4; i++ {
+			_, err := sink.Write([]byte(strconv.Itoa(i)))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, sink.Close())
+		require.Equal(t, []string{
+			"app-2023-01-01T10-00-00.000-1.log",
+			"app-2023-01-01T10-00-00.000-2.log",
+			"app.log",
+		}, listFiles(t, dir))
+
+		data, err := os.ReadFile(filepath.Join(dir, "app-2023-01-01T10-00-00.000-2.log"))
+		require.NoError(t, err)
+		require.Equal(t, "2", string(data))
+	})
+
+	t.Run("should reopen file after failed rotation", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.log")
+		sink, _ := newTestFileSink(t, FileConfig{Path: name}, 0)
+
+		// emulate failed reopen on rotation
+		require.NoError(t, sink.file.Close())
+		sink.file = nil
+
+		_, err := sink.Write([]byte("first\n"))
+		require.NoError(t, err)
+		require.NoError(t, sink.Sync())
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		require.Equal(t, "first\n", string(data))
+	})
+
+	t.Run("should reopen moved file", func(t *testing.T) {
+		dir := t.TempDir()
+		name := filepath.Join(dir, "app.log")
+		sink, _ := newTestFileSink(t, FileConfig{Path: name}, 0)
+
+		_, err := sink.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		// emulate logrotate
+		require.NoError(t, os.Rename(name, name+".1"))
+		require.NoError(t, sink.Reopen())
+
+		_, err = sink.Write([]byte("second\n"))
+		require.NoError(t, err)
+		require.NoError(t, sink.Sync())
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		require.Equal(t, "second\n", string(data))
+
+		require.NoError(t, sink.Close())
+
+		_, err = sink.Write([]byte("closed\n"))
+		require.ErrorIs(t, err, os.ErrClosed)
+		require.NoError(t, sink.Sync())
+	})
+}
+
+func TestFileOutput(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+
+	log, err := New(Config{Level: "info", File: FileConfig{Path: name, MaxSize: 1}})
+	require.NoError(t, err)
+
+	log.Info("to file")
+	require.NoError(t, log.Sync())
+
+	require.NoError(t, os.Rename(name, name+".1"))
+	require.NoError(t, log.Named("child").Reopen())
+
+	log.Info("after reopen")
+	require.NoError(t, log.Sync())
+
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(data), `"msg":"after reopen"`), string(data))
+
+	t.Run("should fail on invalid config", func(t *testing.T) {
+		cfg := Config{Level: "info", Trace: "fatal", File: FileConfig{Path: name, MaxSize: -1, MaxAge: -time.Second}}
+		require.Error(t, cfg.Validate(context.Background()))
+
+		_, err = New(Config{File: FileConfig{Path: filepath.Join(name, "file.log")}})
+		require.Error(t, err)
+	})
+}
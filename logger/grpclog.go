@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/grpclog"
+)
+
+// grpcLogger implements grpclog.DepthLoggerV2 over SugaredLogger.
+type grpcLogger struct {
+	log       *SugaredLogger
+	verbosity int
+}
+
+// grpcCallerSkip skips grpclog package function (e.g. grpclog.Info) and method of grpcLogger.
+const grpcCallerSkip = 2
+
+var _ grpclog.DepthLoggerV2 = (*grpcLogger)(nil)
+
+// GRPCLogger returns grpclog.LoggerV2 (e.g. for grpclog.SetLoggerV2), like default gRPC logger it writes only
+// warnings and errors, gRPC info messages are written with debug level when verbosity is greater than zero,
+// V-levels greater than configured verbosity are disabled.
+func (l *logger) GRPCLogger() grpclog.LoggerV2 {
+	return &grpcLogger{log: l.Sugar(), verbosity: l.verbosity}
+}
+
+func (g *grpcLogger) depth(depth int) *SugaredLogger {
+	return g.log.WithOptions(zap.AddCallerSkip(depth))
+}
+
+// info returns logger for gRPC info messages or nil, when they are disabled.
+func (g *grpcLogger) info(depth int) *SugaredLogger {
+	if g.verbosity <= 0 {
+		return nil
+	}
+
+	return g.depth(depth)
+}
+
+// Info logs to debug level, when verbosity is greater than zero.
+func (g *grpcLogger) Info(args ...interface{}) {
+	if log := g.info(grpcCallerSkip); log != nil {
+		log.Debug(args...)
+	}
+}
+
+// Infoln logs to debug level, when verbosity is greater than zero.
+func (g *grpcLogger) Infoln(args ...interface{}) {
+	if log := g.info(grpcCallerSkip); log != nil {
+		log.Debugln(args...)
+	}
+}
+
+// Infof logs to debug level, when verbosity is greater than zero.
+func (g *grpcLogger) Infof(format string, args ...interface{}) {
+	if log := g.info(grpcCallerSkip); log != nil {
+		log.Debugf(format, args...)
+	}
+}
+
+// InfoDepth logs to debug level at the specified depth, when verbosity is greater than zero.
+func (g *grpcLogger) InfoDepth(depth int, args ...interface{}) {
+	if log := g.info(depth + 1); log != nil {
+		log.Debugln(args...)
+	}
+}
+
+// Warning logs to warn level.
+func (g *grpcLogger) Warning(args ...interface{}) { g.depth(grpcCallerSkip).Warn(args...) }
+
+// Warningln logs to warn level.
+func (g *grpcLogger) Warningln(args ...interface{}) { g.depth(grpcCallerSkip).Warnln(args...) }
+
+// Warningf logs to warn level.
+func (g *grpcLogger) Warningf(format string, args ...interface{}) {
+	g.depth(grpcCallerSkip).Warnf(format, args...)
+}
+
+// WarningDepth logs to warn level at the specified depth.
+func (g *grpcLogger) WarningDepth(depth int, args ...interface{}) { g.depth(depth + 1).Warnln(args...) }
+
+// Error logs to error level.
+func (g *grpcLogger) Error(args ...interface{}) { g.depth(grpcCallerSkip).Error(args...) }
+
+// Errorln logs to error level.
+func (g *grpcLogger) Errorln(args ...interface{}) { g.depth(grpcCallerSkip).Errorln(args...) }
+
+// Errorf logs to error level.
+func (g *grpcLogger) Errorf(format string, args ...interface{}) {
+	g.depth(grpcCallerSkip).Errorf(format, args...)
+}
+
+// ErrorDepth logs to error level at the specified depth.
+func (g *grpcLogger) ErrorDepth(depth int, args ...interface{}) { g.depth(depth + 1).Errorln(args...) }
+
+// Fatal logs to fatal level and exits.
+func (g *grpcLogger) Fatal(args ...interface{}) { g.depth(grpcCallerSkip).Fatal(args...) }
+
+// Fatalln logs to fatal level and exits.
+func (g *grpcLogger) Fatalln(args ...interface{}) { g.depth(grpcCallerSkip).Fatalln(args...) }
+
+// Fatalf logs to fatal level and exits.
+func (g *grpcLogger) Fatalf(format string, args ...interface{}) {
+	g.depth(grpcCallerSkip).Fatalf(format, args...)
+}
+
+// FatalDepth logs to fatal level at the specified depth and exits.
+func (g *grpcLogger) FatalDepth(depth int, args ...interface{}) { g.depth(depth + 1).Fatalln(args...) }
+
+// V reports whether verbosity level l is enabled.
+func (g *grpcLogger) V(l int) bool { return l <= g.verbosity }
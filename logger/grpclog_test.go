@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc/grpclog"
+)
+
+func TestGRPCLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	prepare := func(verbosity int) grpclog.DepthLoggerV2 {
+		log := &logger{verbosity: verbosity, SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+
+		return log.GRPCLogger().(grpclog.DepthLoggerV2)
+	}
+
+	t.Run("should skip info messages by default", func(t *testing.T) {
+		log := prepare(0)
+		require.True(t, log.V(0))
+		require.False(t, log.V(1))
+
+		log.Info("skipped")
+		log.Infoln("skipped")
+		log.Infof("skipped %d", 1)
+		log.InfoDepth(0, "skipped")
+
+		log.Warning("warning")
+		log.Errorf("error %d", 1)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+		require.Equal(t, zapcore.WarnLevel, entries[0].Level)
+		require.Equal(t, "warning", entries[0].Message)
+		require.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+		require.Equal(t, "error 1", entries[1].Message)
+	})
+
+	t.Run("should write info messages with debug level", func(t *testing.T) {
+		log := prepare(2)
+		require.True(t, log.V(2))
+		require.False(t, log.V(3))
+
+		log.Info("info")
+		log.Infoln("info", 1)
+		log.Infof("info %d", 2)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 3)
+
+		for i, msg := range []string{"info", "info 1", "info 2"} {
+			require.Equal(t, zapcore.DebugLevel, entries[i].Level)
+			require.Equal(t, msg, entries[i].Message)
+		}
+	})
+
+	t.Run("should set caller by depth", func(t *testing.T) {
+		log := prepare(1)
+
+		log.InfoDepth(0, "info")
+		log.WarningDepth(0, "warning")
+		log.ErrorDepth(0, "error")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 3)
+
+		for _, entry := range entries {
+			require.Equal(t, "grpclog_test.go", filepath.Base(entry.Caller.File))
+		}
+	})
+}
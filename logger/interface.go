@@ -1,10 +1,15 @@
 package logger
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"time"
 
+	"github.com/go-logr/logr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/grpclog"
 )
 
 // WriteSyncer is an io.Writer that can also flush any buffered data. Note
@@ -27,34 +32,56 @@ type Logger interface {
 	Debug(...interface{})
 	Debugf(msg string, args ...interface{})
 	Debugw(msg string, keysAndValues ...interface{})
+	DebugwCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	Info(...interface{})
 	Infof(msg string, args ...interface{})
 	Infow(msg string, keysAndValues ...interface{})
+	InfowCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	Warn(...interface{})
 	Warnf(msg string, args ...interface{})
 	Warnw(msg string, keysAndValues ...interface{})
+	WarnwCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	Error(...interface{})
 	Errorf(msg string, args ...interface{})
 	Errorw(msg string, keysAndValues ...interface{})
+	ErrorwCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	Fatal(...interface{})
 	Fatalf(msg string, args ...interface{})
 	Fatalw(msg string, keysAndValues ...interface{})
+	FatalwCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	Panic(...interface{})
 	Panicf(msg string, args ...interface{})
 	Panicw(msg string, keysAndValues ...interface{})
+	PanicwCtx(ctx context.Context, msg string, keysAndValues ...interface{})
 
 	With(...interface{}) Logger
 
 	Named(string) Logger
 
+	Every(time.Duration) Logger
+
 	Sugar() *SugaredLogger
 
+	Levels() *Levels
+
+	Buffer() *Buffer
+
 	Std() *log.Logger
 
+	Slog() *slog.Logger
+
+	Logr() logr.Logger
+
+	GRPCLogger() grpclog.LoggerV2
+
 	Sync() error
+
+	Close() error
+
+	Reopen() error
 }
@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// JournaldConfig provides configuration of native journald output.
+type JournaldConfig struct {
+	Enabled bool   `env:"ENABLED" default:"false" usage:"allows to write logs to journald"`
+	Socket  string `env:"SOCKET" default:"/run/systemd/journal/socket" usage:"allows to set journald socket path"`
+}
+
+// journaldCore writes entries with structured fields by journald native protocol,
+// field names are converted to upper case (e.g. `user.id` is written as `USER_ID`),
+// fields, that collide with fields written by core (e.g. `message`), are prefixed (e.g. `FIELD_MESSAGE`).
+type journaldCore struct {
+	zapcore.LevelEnabler
+
+	out        *socketWriter
+	fields     []zapcore.Field
+	identifier string
+}
+
+const defaultJournaldSocket = "/run/systemd/journal/socket"
+
+var _ zapcore.Core = (*journaldCore)(nil)
+
+// journaldReserved contains fields written by journaldCore, structured fields should not override them.
+// nolint: gochecknoglobals
+var journaldReserved = map[string]struct{}{
+	"MESSAGE":           {},
+	"MESSAGE_ID":        {},
+	"PRIORITY":          {},
+	"SYSLOG_IDENTIFIER": {},
+	"LOGGER":            {},
+	"CODE_FILE":         {},
+	"CODE_LINE":         {},
+	"CODE_FUNC":         {},
+	"STACKTRACE":        {},
+}
+
+func newJournaldCore(cfg JournaldConfig, appName string) *journaldCore {
+	socket := cfg.Socket
+	if socket == "" {
+		socket = defaultJournaldSocket
+	}
+
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+
+	return &journaldCore{
+		LevelEnabler: zapcore.DebugLevel,
+
+		out:        newSocketWriter("unixgram", socket),
+		identifier: appName,
+	}
+}
+
+// With adds structured context to the core.
+func (c *journaldCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(append(clone.fields, c.fields...), fields...)
+
+	return &clone
+}
+
+// Check adds core to checked entry, levels are checked by wrapped core.
+func (c *journaldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write sends entry with well-known journald fields (MESSAGE, PRIORITY, SYSLOG_IDENTIFIER, CODE_*)
+// and structured fields.
+func (c *journaldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	out := new(bytes.Buffer)
+	journaldAppend(out, "MESSAGE", ent.Message)
+	journaldAppend(out, "PRIORITY", strconv.Itoa(syslogSeverity(ent.Level)))
+	journaldAppend(out, "SYSLOG_IDENTIFIER", c.identifier)
+
+	if ent.LoggerName != "" {
+		journaldAppend(out, "LOGGER", ent.LoggerName)
+	}
+
+	if ent.Caller.Defined {
+		journaldAppend(out, "CODE_FILE", ent.Caller.File)
+		journaldAppend(out, "CODE_LINE", strconv.Itoa(ent.Caller.Line))
+		journaldAppend(out, "CODE_FUNC", ent.Caller.Function)
+	}
+
+	if ent.Stack != "" {
+		journaldAppend(out, "STACKTRACE", ent.Stack)
+	}
+
+	journaldFields(out, "", enc.Fields)
+
+	return c.out.write(out.Bytes())
+}
+
+// Sync does nothing, because messages are not buffered.
+func (c *journaldCore) Sync() error { return nil }
+
+// journaldFields appends structured fields sorted by key, nested objects are flattened.
+func journaldFields(out *bytes.Buffer, prefix string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch value := fields[key].(type) {
+		case map[string]interface{}:
+			journaldFields(out, prefix+key+"_", value)
+		case string:
+			journaldAppend(out, journaldKey(prefix+key), value)
+		case []interface{}:
+			data, _ := json.Marshal(value)
+			journaldAppend(out, journaldKey(prefix+key), string(data))
+		default:
+			journaldAppend(out, journaldKey(prefix+key), fmt.Sprint(value))
+		}
+	}
+}
+
+// journaldKey converts key to journald field name, that contains only upper case letters, digits and underscores,
+// does not start with underscore (such fields are trusted and set by journald) and is not reserved.
+func journaldKey(key string) string {
+	out := []byte(strings.ToUpper(key))
+	for i, c := range out {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			out[i] = '_'
+		}
+	}
+
+	if key = strings.TrimLeft(string(out), "_"); key == "" || key[0] <= '9' {
+		key = "FIELD_" + key
+	} else if _, ok := journaldReserved[key]; ok {
+		key = "FIELD_" + key
+	}
+
+	return key
+}
+
+// journaldAppend appends field, values with new lines are written in binary format.
+func journaldAppend(out *bytes.Buffer, key, value string) {
+	out.WriteString(key)
+
+	if !strings.ContainsRune(value, '\n') {
+		out.WriteByte('=')
+		out.WriteString(value)
+		out.WriteByte('\n')
+
+		return
+	}
+
+	out.WriteByte('\n')
+	_ = binary.Write(out, binary.LittleEndian, uint64(len(value)))
+	out.WriteString(value)
+	out.WriteByte('\n')
+}
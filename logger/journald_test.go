@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// parseJournald parses fields written by journald native protocol.
+func parseJournald(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	out := make(map[string]string)
+	for len(data) > 0 {
+		line := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(t, line, 0)
+
+		if eq := bytes.IndexByte(data[:line], '='); eq >= 0 {
+			out[string(data[:eq])] = string(data[eq+1 : line])
+			data = data[line+1:]
+
+			continue
+		}
+
+		key := string(data[:line])
+		size := int(binary.LittleEndian.Uint64(data[line+1 : line+9]))
+		out[key] = string(data[line+9 : line+9+size])
+		data = data[line+9+size+1:]
+	}
+
+	return out
+}
+
+func TestJournaldCore(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, conn.Close()) }()
+
+	log, err := New(Config{Level: "info", Trace: "error", Redact: "*token",
+		Journald: JournaldConfig{Enabled: true, Socket: socket}},
+		WithAppName("journald-test"))
+	require.NoError(t, err)
+
+	read := func() map[string]string {
+		buf := make([]byte, 64*1024)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+		n, errRead := conn.Read(buf)
+		require.NoError(t, errRead)
+
+		return parseJournald(t, buf[:n])
+	}
+
+	t.Run("should write structured fields", func(t *testing.T) {
+		log.Named("worker").With("request.id", 42).Warnw("journald message",
+			"user", map[string]interface{}{"name": "admin", "token": "secret"},
+			"tags", []string{"a", "b"},
+			"_hidden", true)
+
+		fields := read()
+		require.Equal(t, "journald message", fields["MESSAGE"])
+		require.Equal(t, "4", fields["PRIORITY"])
+		require.Equal(t, "journald-test", fields["SYSLOG_IDENTIFIER"])
+		require.Equal(t, "worker", fields["LOGGER"])
+		require.Contains(t, fields["CODE_FILE"], "journald_test.go")
+		require.NotEmpty(t, fields["CODE_LINE"])
+		require.Equal(t, "42", fields["REQUEST_ID"])
+		require.Equal(t, "admin", fields["USER_NAME"])
+		require.Equal(t, RedactedValue, fields["USER_TOKEN"])
+		require.Equal(t, `["a","b"]`, fields["TAGS"])
+		require.Equal(t, "true", fields["HIDDEN"])
+		require.Equal(t, "journald-test", fields["APP"])
+	})
+
+	t.Run("should write multiline values and stacktrace", func(t *testing.T) {
+		log.Errorw("first line\nsecond line")
+
+		fields := read()
+		require.Equal(t, "first line\nsecond line", fields["MESSAGE"])
+		require.Equal(t, "3", fields["PRIORITY"])
+		require.NotEmpty(t, fields["STACKTRACE"])
+	})
+
+	t.Run("should skip disabled levels", func(t *testing.T) {
+		log.Debug("disabled")
+		log.Info("enabled")
+
+		require.Equal(t, "enabled", read()["MESSAGE"])
+	})
+
+	t.Run("should convert keys", func(t *testing.T) {
+		require.Equal(t, "HTTP_STATUS", journaldKey("http-status"))
+		require.Equal(t, "FIELD_1ST", journaldKey("1st"))
+		require.Equal(t, "FIELD_", journaldKey("__"))
+		require.Equal(t, "FIELD_PRIORITY", journaldKey("_priority"))
+		require.Equal(t, "FIELD_MESSAGE", journaldKey("message"))
+		require.Equal(t, "MESSAGE_TEXT", journaldKey("message.text"))
+	})
+
+	t.Run("should return error when journald is not available", func(t *testing.T) {
+		core := newJournaldCore(JournaldConfig{Socket: filepath.Join(t.TempDir(), "unknown.sock")}, "")
+		require.Equal(t, filepath.Base(core.identifier), core.identifier)
+		require.Error(t, core.Write(zapcore.Entry{Message: "message"}, nil))
+	})
+}
@@ -0,0 +1,293 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels allows to change level of the logger (and all its children) at runtime.
+// Named levels override global level for loggers with matched name prefix
+// (e.g. `grpc` is applied to `grpc` and `grpc.client` loggers), the longest prefix wins.
+// Temporary levels are reverted to the permanent level after their TTL.
+type Levels struct {
+	mu sync.Mutex
+
+	atom    zap.AtomicLevel
+	base    zapcore.Level
+	timer   *time.Timer
+	expires time.Time
+
+	named    map[string]*namedLevel
+	snapshot atomic.Pointer[namedSnapshot]
+}
+
+// LevelState describes current level and time when temporary level will be reverted.
+type LevelState struct {
+	Level   zapcore.Level
+	Expires time.Time
+}
+
+type namedLevel struct {
+	level   zapcore.Level
+	base    *zapcore.Level // nil when level was set temporarily
+	timer   *time.Timer
+	expires time.Time
+}
+
+// namedSnapshot is an immutable copy of named levels, that used on each log entry.
+type namedSnapshot struct {
+	names  []string // sorted by length, the longest first
+	levels map[string]zapcore.Level
+	min    zapcore.Level
+}
+
+// levelCore filters entries by global and named levels, wrapped core should enable all levels.
+type levelCore struct {
+	zapcore.Core
+
+	levels *Levels
+}
+
+func newLevels(atom zap.AtomicLevel) *Levels {
+	out := &Levels{atom: atom, base: atom.Level(), named: make(map[string]*namedLevel)}
+	out.publish()
+
+	return out
+}
+
+// parseNamedLevels parses named levels in `name=level,other=level` format.
+func parseNamedLevels(v string) (map[string]zapcore.Level, error) {
+	out := make(map[string]zapcore.Level)
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, fmt.Errorf("invalid named level %q, expected name=level", item)
+		}
+
+		lvl, err := zapcore.ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid named level %q: %w", item, err)
+		}
+
+		out[name] = lvl
+	}
+
+	return out, nil
+}
+
+// Atomic returns zap.AtomicLevel used by logger.
+func (l *Levels) Atomic() zap.AtomicLevel { return l.atom }
+
+// Level returns current logger level.
+func (l *Levels) Level() zapcore.Level { return l.atom.Level() }
+
+// Expires returns time when temporary level will be reverted, zero for permanent level.
+func (l *Levels) Expires() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.expires
+}
+
+// SetLevel changes logger level, when ttl is greater than zero
+// level will be reverted to the permanent level after ttl.
+func (l *Levels) SetLevel(lvl zapcore.Level, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stop()
+	l.atom.SetLevel(lvl)
+
+	if ttl <= 0 {
+		l.base = lvl
+
+		return
+	}
+
+	l.expires = time.Now().Add(ttl)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		// level was changed after timer fired
+		if l.timer != timer {
+			return
+		}
+
+		l.stop()
+		l.atom.SetLevel(l.base)
+	})
+
+	l.timer = timer
+}
+
+// Named returns current named levels.
+func (l *Levels) Named() map[string]LevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make(map[string]LevelState, len(l.named))
+	for name, item := range l.named {
+		out[name] = LevelState{Level: item.level, Expires: item.expires}
+	}
+
+	return out
+}
+
+// SetNamedLevel changes level of loggers with passed name prefix, when ttl is greater than zero
+// level will be reverted to the permanent named level (or removed, when it was not set) after ttl.
+func (l *Levels) SetNamedLevel(name string, lvl zapcore.Level, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	item, ok := l.named[name]
+	if !ok {
+		item = new(namedLevel)
+		l.named[name] = item
+	}
+
+	item.stop()
+	item.level = lvl
+
+	defer l.publish()
+
+	if ttl <= 0 {
+		item.base = &lvl
+
+		return
+	}
+
+	item.expires = time.Now().Add(ttl)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		// level was changed after timer fired
+		if item.timer != timer {
+			return
+		}
+
+		l.restoreNamed(name, item)
+		l.publish()
+	})
+
+	item.timer = timer
+}
+
+// Restore reverts all temporary levels to the permanent levels.
+func (l *Levels) Restore() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stop()
+	l.atom.SetLevel(l.base)
+
+	for name, item := range l.named {
+		l.restoreNamed(name, item)
+	}
+
+	l.publish()
+}
+
+func (l *Levels) stop() {
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+
+	l.timer = nil
+	l.expires = time.Time{}
+}
+
+func (l *Levels) restoreNamed(name string, item *namedLevel) {
+	item.stop()
+
+	if item.base == nil {
+		delete(l.named, name)
+
+		return
+	}
+
+	item.level = *item.base
+}
+
+func (n *namedLevel) stop() {
+	if n.timer != nil {
+		n.timer.Stop()
+	}
+
+	n.timer = nil
+	n.expires = time.Time{}
+}
+
+// publish stores snapshot of named levels, should be called under lock.
+func (l *Levels) publish() {
+	out := &namedSnapshot{
+		names:  make([]string, 0, len(l.named)),
+		levels: make(map[string]zapcore.Level, len(l.named)),
+		min:    zapcore.InvalidLevel,
+	}
+
+	for name, item := range l.named {
+		out.names = append(out.names, name)
+		out.levels[name] = item.level
+
+		if item.level < out.min {
+			out.min = item.level
+		}
+	}
+
+	sort.Slice(out.names, func(i, j int) bool { return len(out.names[i]) > len(out.names[j]) })
+
+	l.snapshot.Store(out)
+}
+
+// enabled reports whether entry of passed level and logger name should be logged.
+func (l *Levels) enabled(name string, lvl zapcore.Level) bool {
+	snapshot := l.snapshot.Load()
+	for _, prefix := range snapshot.names {
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return snapshot.levels[prefix].Enabled(lvl)
+		}
+	}
+
+	return l.atom.Enabled(lvl)
+}
+
+// wrapCore returns core, that filters entries by global and named levels.
+func (l *Levels) wrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+// Enabled returns true when level is enabled by global or any of named levels.
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.levels.atom.Enabled(lvl) || lvl >= c.levels.snapshot.Load().min
+}
+
+// With adds structured context to the wrapped core.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check passes entry to the wrapped core, when it is enabled for the logger name.
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabled(ent.LoggerName, ent.Level) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
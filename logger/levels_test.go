@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevels(t *testing.T) {
+	t.Run("should keep levels for children", func(t *testing.T) {
+		log, err := New(Config{Level: "warn"})
+		require.NoError(t, err)
+
+		levels := log.Levels()
+		require.Equal(t, zapcore.WarnLevel, levels.Level())
+		require.Equal(t, levels, log.Named("child").Levels())
+		require.Equal(t, levels, log.With("key", "val").Levels())
+
+		levels.SetLevel(zapcore.DebugLevel, 0)
+		require.True(t, log.Named("child").Sugar().Desugar().Core().Enabled(zapcore.DebugLevel))
+		require.True(t, levels.Expires().IsZero())
+	})
+
+	t.Run("should use custom level", func(t *testing.T) {
+		atom := zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+
+		log, err := New(Config{Level: "info"}, WithCustomLevel(atom))
+		require.NoError(t, err)
+		require.Equal(t, zapcore.ErrorLevel, log.Levels().Level())
+
+		log.Levels().SetLevel(zapcore.DebugLevel, 0)
+		require.Equal(t, zapcore.DebugLevel, atom.Level())
+	})
+
+	t.Run("should revert temporary level", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+		levels.SetLevel(zapcore.DebugLevel, time.Millisecond*10)
+		require.Equal(t, zapcore.DebugLevel, levels.Level())
+		require.False(t, levels.Expires().IsZero())
+
+		require.Eventually(t, func() bool {
+			return levels.Level() == zapcore.InfoLevel && levels.Expires().IsZero()
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not revert replaced level", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+		levels.SetLevel(zapcore.DebugLevel, time.Millisecond)
+		levels.SetLevel(zapcore.WarnLevel, 0)
+
+		time.Sleep(time.Millisecond * 10)
+		require.Equal(t, zapcore.WarnLevel, levels.Level())
+	})
+
+	t.Run("should restore permanent level", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+
+		levels.SetLevel(zapcore.DebugLevel, time.Hour)
+		levels.Restore()
+
+		require.Equal(t, zapcore.InfoLevel, levels.Level())
+		require.True(t, levels.Expires().IsZero())
+	})
+
+	t.Run("should apply named levels", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+		levels.SetNamedLevel("grpc", zapcore.WarnLevel, 0)
+		levels.SetNamedLevel("grpc.client", zapcore.DebugLevel, 0)
+
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := &logger{levels: levels, SugaredLogger: zap.New(levels.wrapCore(core)).Sugar()}
+
+		log.Debug("root debug") // skipped
+		log.Info("root info")
+		log.Named("grpc").Info("grpc info") // skipped
+		log.Named("grpc").Warn("grpc warn")
+		log.Named("grpc").Named("client").Debug("client debug")
+		log.Named("grpcx").Info("grpcx info")
+
+		levels.SetNamedLevel("grpc", zapcore.DebugLevel, time.Hour)
+		log.Named("grpc").Debug("grpc debug")
+
+		levels.Restore()
+		log.Named("grpc").Debug("grpc debug") // skipped
+
+		var messages []string
+		for _, entry := range logs.TakeAll() {
+			messages = append(messages, entry.Message)
+		}
+
+		require.Equal(t, []string{"root info", "grpc warn", "client debug", "grpcx info", "grpc debug"}, messages)
+		require.Equal(t, map[string]LevelState{
+			"grpc":        {Level: zapcore.WarnLevel},
+			"grpc.client": {Level: zapcore.DebugLevel},
+		}, levels.Named())
+	})
+
+	t.Run("should remove temporary named level", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+		levels.SetNamedLevel("repo", zapcore.DebugLevel, time.Millisecond)
+
+		require.Eventually(t, func() bool { return len(levels.Named()) == 0 }, time.Second, time.Millisecond)
+		require.False(t, levels.enabled("repo", zapcore.DebugLevel))
+	})
+
+	t.Run("should parse named levels from config", func(t *testing.T) {
+		log, err := New(Config{Level: "info", Levels: "grpc=warn, repo=debug,"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]LevelState{
+			"grpc": {Level: zapcore.WarnLevel},
+			"repo": {Level: zapcore.DebugLevel},
+		}, log.Levels().Named())
+
+		for _, invalid := range []string{"grpc", "=warn", "grpc=unknown"} {
+			_, err = New(Config{Level: "info", Levels: invalid})
+			require.Error(t, err, invalid)
+
+			cfg := Config{Level: "info", Trace: "fatal", Levels: invalid}
+			require.Error(t, cfg.Validate(context.Background()), invalid)
+		}
+	})
+}
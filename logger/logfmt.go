@@ -0,0 +1,359 @@
+package logger
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// logfmtEncoder implements zapcore.Encoder that writes entries as `key=value` pairs,
+// nested objects and namespaces are flattened with dot separated keys, arrays and reflected values are written as JSON.
+type logfmtEncoder struct {
+	cfg    *zapcore.EncoderConfig
+	buf    *buffer.Buffer
+	prefix string
+}
+
+// logfmtValues collects values appended by encoders of zapcore.EncoderConfig.
+type logfmtValues []string
+
+// nolint: gochecknoglobals
+var (
+	logfmtPool = buffer.NewPool()
+
+	logfmtOnce sync.Once
+	logfmtErr  error
+)
+
+var _ zapcore.Encoder = (*logfmtEncoder)(nil)
+
+// NewLogfmtEncoder creates encoder that writes entries in logfmt format.
+func NewLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{cfg: &cfg, buf: logfmtPool.Get()}
+}
+
+// registerLogfmt registers logfmt encoding, so it could be used in zap.Config.
+func registerLogfmt() error {
+	logfmtOnce.Do(func() {
+		logfmtErr = zap.RegisterEncoder(EncodingLogfmt, func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return NewLogfmtEncoder(cfg), nil
+		})
+	})
+
+	return logfmtErr
+}
+
+func (e *logfmtEncoder) addKey(key string) {
+	if e.buf.Len() > 0 {
+		e.buf.AppendByte(' ')
+	}
+
+	e.appendString(e.prefix + key)
+	e.buf.AppendByte('=')
+}
+
+// appendString writes value, quotes it when it is empty or contains spaces, quotes, `=` or control characters.
+func (e *logfmtEncoder) appendString(value string) {
+	quote := value == "" || !utf8.ValidString(value) || strings.IndexFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == 0x7f
+	}) >= 0
+
+	if quote {
+		e.buf.AppendString(strconv.Quote(value))
+
+		return
+	}
+
+	e.buf.AppendString(value)
+}
+
+func (e *logfmtEncoder) appendJSON(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	e.addKey(key)
+	e.appendString(string(data))
+
+	return nil
+}
+
+// AddArray writes array as JSON.
+func (e *logfmtEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddArray(key, arr); err != nil {
+		return err
+	}
+
+	return e.appendJSON(key, enc.Fields[key])
+}
+
+// AddObject writes object fields with `key.` prefix.
+func (e *logfmtEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	prefix := e.prefix
+	defer func() { e.prefix = prefix }()
+
+	e.prefix += key + "."
+
+	return obj.MarshalLogObject(e)
+}
+
+// AddBinary writes value encoded with base64.
+func (e *logfmtEncoder) AddBinary(key string, value []byte) {
+	e.AddString(key, base64.StdEncoding.EncodeToString(value))
+}
+
+// AddByteString writes UTF-8 value.
+func (e *logfmtEncoder) AddByteString(key string, value []byte) { e.AddString(key, string(value)) }
+
+// AddBool writes bool value.
+func (e *logfmtEncoder) AddBool(key string, value bool) {
+	e.addKey(key)
+	e.buf.AppendBool(value)
+}
+
+// AddComplex128 writes complex value.
+func (e *logfmtEncoder) AddComplex128(key string, value complex128) {
+	e.addKey(key)
+	e.buf.AppendString(strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+// AddComplex64 writes complex value.
+func (e *logfmtEncoder) AddComplex64(key string, value complex64) {
+	e.addKey(key)
+	e.buf.AppendString(strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+// AddDuration writes duration by configured encoder (nanoseconds by default).
+func (e *logfmtEncoder) AddDuration(key string, value time.Duration) {
+	if e.cfg.EncodeDuration == nil {
+		e.AddInt64(key, int64(value))
+
+		return
+	}
+
+	e.addKey(key)
+	e.appendValues(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeDuration(value, enc) })
+}
+
+// AddFloat64 writes float value.
+func (e *logfmtEncoder) AddFloat64(key string, value float64) {
+	e.addKey(key)
+	e.appendFloat(value, 64)
+}
+
+// AddFloat32 writes float value.
+func (e *logfmtEncoder) AddFloat32(key string, value float32) {
+	e.addKey(key)
+	e.appendFloat(float64(value), 32)
+}
+
+func (e *logfmtEncoder) appendFloat(value float64, bitSize int) {
+	switch {
+	case math.IsNaN(value):
+		e.buf.AppendString("NaN")
+	case math.IsInf(value, 1):
+		e.buf.AppendString("+Inf")
+	case math.IsInf(value, -1):
+		e.buf.AppendString("-Inf")
+	default:
+		e.buf.AppendFloat(value, bitSize)
+	}
+}
+
+// AddInt writes int value.
+func (e *logfmtEncoder) AddInt(key string, value int) { e.AddInt64(key, int64(value)) }
+
+// AddInt64 writes int value.
+func (e *logfmtEncoder) AddInt64(key string, value int64) {
+	e.addKey(key)
+	e.buf.AppendInt(value)
+}
+
+// AddInt32 writes int value.
+func (e *logfmtEncoder) AddInt32(key string, value int32) { e.AddInt64(key, int64(value)) }
+
+// AddInt16 writes int value.
+func (e *logfmtEncoder) AddInt16(key string, value int16) { e.AddInt64(key, int64(value)) }
+
+// AddInt8 writes int value.
+func (e *logfmtEncoder) AddInt8(key string, value int8) { e.AddInt64(key, int64(value)) }
+
+// AddString writes string value.
+func (e *logfmtEncoder) AddString(key, value string) {
+	e.addKey(key)
+	e.appendString(value)
+}
+
+// AddTime writes time by configured encoder (unix nanoseconds by default).
+func (e *logfmtEncoder) AddTime(key string, value time.Time) {
+	if e.cfg.EncodeTime == nil {
+		e.AddInt64(key, value.UnixNano())
+
+		return
+	}
+
+	e.addKey(key)
+	e.appendValues(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeTime(value, enc) })
+}
+
+// AddUint writes uint value.
+func (e *logfmtEncoder) AddUint(key string, value uint) { e.AddUint64(key, uint64(value)) }
+
+// AddUint64 writes uint value.
+func (e *logfmtEncoder) AddUint64(key string, value uint64) {
+	e.addKey(key)
+	e.buf.AppendUint(value)
+}
+
+// AddUint32 writes uint value.
+func (e *logfmtEncoder) AddUint32(key string, value uint32) { e.AddUint64(key, uint64(value)) }
+
+// AddUint16 writes uint value.
+func (e *logfmtEncoder) AddUint16(key string, value uint16) { e.AddUint64(key, uint64(value)) }
+
+// AddUint8 writes uint value.
+func (e *logfmtEncoder) AddUint8(key string, value uint8) { e.AddUint64(key, uint64(value)) }
+
+// AddUintptr writes pointer value.
+func (e *logfmtEncoder) AddUintptr(key string, value uintptr) { e.AddUint64(key, uint64(value)) }
+
+// AddReflected writes value as JSON.
+func (e *logfmtEncoder) AddReflected(key string, value interface{}) error {
+	return e.appendJSON(key, value)
+}
+
+// OpenNamespace adds `key.` prefix to the following fields.
+func (e *logfmtEncoder) OpenNamespace(key string) { e.prefix += key + "." }
+
+// Clone copies encoder with added fields.
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	out := &logfmtEncoder{cfg: e.cfg, buf: logfmtPool.Get(), prefix: e.prefix}
+	_, _ = out.buf.Write(e.buf.Bytes())
+
+	return out
+}
+
+// EncodeEntry writes entry, context and passed fields into buffer.
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	out := &logfmtEncoder{cfg: e.cfg, buf: logfmtPool.Get()}
+
+	if e.cfg.TimeKey != "" && !ent.Time.IsZero() {
+		out.AddTime(e.cfg.TimeKey, ent.Time)
+	}
+
+	if e.cfg.LevelKey != "" && e.cfg.EncodeLevel != nil {
+		out.addKey(e.cfg.LevelKey)
+		out.appendValues(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeLevel(ent.Level, enc) })
+	}
+
+	if e.cfg.NameKey != "" && ent.LoggerName != "" {
+		out.addKey(e.cfg.NameKey)
+		if e.cfg.EncodeName == nil {
+			out.appendString(ent.LoggerName)
+		} else {
+			out.appendValues(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeName(ent.LoggerName, enc) })
+		}
+	}
+
+	if e.cfg.CallerKey != "" && ent.Caller.Defined && e.cfg.EncodeCaller != nil {
+		out.addKey(e.cfg.CallerKey)
+		out.appendValues(func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeCaller(ent.Caller, enc) })
+	}
+
+	if e.cfg.FunctionKey != "" && ent.Caller.Defined {
+		out.AddString(e.cfg.FunctionKey, ent.Caller.Function)
+	}
+
+	if e.cfg.MessageKey != "" {
+		out.AddString(e.cfg.MessageKey, ent.Message)
+	}
+
+	if e.buf.Len() > 0 {
+		if out.buf.Len() > 0 {
+			out.buf.AppendByte(' ')
+		}
+
+		_, _ = out.buf.Write(e.buf.Bytes())
+	}
+
+	out.prefix = e.prefix
+	for _, field := range fields {
+		field.AddTo(out)
+	}
+
+	out.prefix = ""
+	if e.cfg.StacktraceKey != "" && ent.Stack != "" {
+		out.AddString(e.cfg.StacktraceKey, ent.Stack)
+	}
+
+	if e.cfg.LineEnding != "" {
+		out.buf.AppendString(e.cfg.LineEnding)
+	} else {
+		out.buf.AppendString(zapcore.DefaultLineEnding)
+	}
+
+	return out.buf, nil
+}
+
+// appendValues writes values appended by encoder, multiple values are joined by comma.
+func (e *logfmtEncoder) appendValues(encode func(zapcore.PrimitiveArrayEncoder)) {
+	var values logfmtValues
+
+	encode(&values)
+	e.appendString(strings.Join(values, ","))
+}
+
+func (v *logfmtValues) AppendBool(value bool) { *v = append(*v, strconv.FormatBool(value)) }
+
+func (v *logfmtValues) AppendByteString(value []byte) { *v = append(*v, string(value)) }
+
+func (v *logfmtValues) AppendComplex128(value complex128) {
+	*v = append(*v, strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+func (v *logfmtValues) AppendComplex64(value complex64) {
+	*v = append(*v, strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+func (v *logfmtValues) AppendFloat64(value float64) {
+	*v = append(*v, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (v *logfmtValues) AppendFloat32(value float32) {
+	*v = append(*v, strconv.FormatFloat(float64(value), 'g', -1, 32))
+}
+
+func (v *logfmtValues) AppendInt(value int) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt64(value int64) { *v = append(*v, strconv.FormatInt(value, 10)) }
+
+func (v *logfmtValues) AppendInt32(value int32) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt16(value int16) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt8(value int8) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendString(value string) { *v = append(*v, value) }
+
+func (v *logfmtValues) AppendUint(value uint) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint64(value uint64) { *v = append(*v, strconv.FormatUint(value, 10)) }
+
+func (v *logfmtValues) AppendUint32(value uint32) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint16(value uint16) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint8(value uint8) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUintptr(value uintptr) { v.AppendUint64(uint64(value)) }
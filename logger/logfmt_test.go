@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogfmtEncoder(t *testing.T) {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.RFC3339TimeEncoder
+	cfg.EncodeDuration = zapcore.StringDurationEncoder
+
+	ent := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		LoggerName: "worker",
+		Message:    "hello world",
+		Caller:     zapcore.NewEntryCaller(0, "/app/worker/main.go", 42, true),
+	}
+
+	cases := []struct {
+		name   string
+		ent    zapcore.Entry
+		with   []zapcore.Field
+		fields []zapcore.Field
+		expect string
+	}{
+		{
+			name:   "should write entry",
+			ent:    ent,
+			expect: `ts=2023-01-02T03:04:05Z level=warn logger=worker caller=worker/main.go:42 msg="hello world"` + "\n",
+		},
+
+		{
+			name: "should quote values",
+			ent:  zapcore.Entry{Message: "ok"},
+			fields: []zapcore.Field{
+				zap.String("empty", ""),
+				zap.String("quote", `say "hi"`),
+				zap.String("equal", "a=b"),
+				zap.String("line", "a\nb"),
+				zap.Error(errors.New("failed")),
+			},
+			expect: `level=info msg=ok empty="" quote="say \"hi\"" equal="a=b" line="a\nb" error=failed` + "\n",
+		},
+
+		{
+			name: "should write primitives",
+			ent:  zapcore.Entry{Message: "ok"},
+			fields: []zapcore.Field{
+				zap.Bool("bool", true),
+				zap.Int("int", -1),
+				zap.Uint8("uint", 2),
+				zap.Float64("float", 1.5),
+				zap.Float64("nan", math.NaN()),
+				zap.Duration("took", time.Second),
+				zap.Binary("bin", []byte("hi")),
+			},
+			expect: `level=info msg=ok bool=true int=-1 uint=2 float=1.5 nan=NaN took=1s bin="aGk="` + "\n",
+		},
+
+		{
+			name: "should flatten objects and namespaces",
+			ent:  zapcore.Entry{Message: "ok"},
+			with: []zapcore.Field{zap.String("app", "test"), zap.Namespace("req")},
+			fields: []zapcore.Field{
+				zap.Int("id", 1),
+				zap.Object("user", redactUser{Name: "admin", Password: "secret"}),
+			},
+			expect: `level=info msg=ok app=test req.id=1 req.user.name=admin req.user.password=secret` + "\n",
+		},
+
+		{
+			name: "should write arrays and reflected values as json",
+			ent:  zapcore.Entry{Message: "ok", Stack: "main.go:1"},
+			fields: []zapcore.Field{
+				zap.Strings("list", []string{"a", "b"}),
+				zap.Any("map", map[string]int{"a": 1}),
+			},
+			expect: `level=info msg=ok list="[\"a\",\"b\"]" map="{\"a\":1}" stacktrace=main.go:1` + "\n",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewLogfmtEncoder(cfg)
+			for _, field := range tt.with {
+				field.AddTo(enc)
+			}
+
+			buf, err := enc.Clone().EncodeEntry(tt.ent, tt.fields)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, buf.String())
+		})
+	}
+}
@@ -2,11 +2,17 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
@@ -14,10 +20,27 @@ import (
 
 // Config structure that provides configuration of logger module.
 type Config struct {
-	EncodingConsole bool   `env:"ENCODING_CONSOLE" default:"false" usage:"allows to set user-friendly formatting"`
-	Level           string `env:"LEVEL" default:"info" usage:"allows to set custom logger level"`
-	Trace           string `env:"TRACE" default:"fatal" usage:"allows to set custom trace level"`
+	EncodingConsole bool   `env:"ENCODING_CONSOLE" default:"false" usage:"allows to set user-friendly formatting (same as console encoding)"`
+	Encoding        string `env:"ENCODING" default:"json" enum:"json,console,logfmt" usage:"allows to set logs encoding"`
+	Level           string `env:"LEVEL" default:"info" enum:"info,debug,warn,error,dpanic,panic,fatal" usage:"allows to set logger level"`
+	Trace           string `env:"TRACE" default:"fatal" enum:"info,debug,warn,error,dpanic,panic,fatal" usage:"allows to set trace level"`
 	SampleRate      *int   `env:"SAMPLE_RATE" default:"1000" usage:"allows to set sample rate"`
+	Levels          string `env:"LEVELS" usage:"allows to override level by logger name prefix" example:"grpc=warn,repo=debug"`
+	Verbosity       int    `env:"VERBOSITY" default:"0" usage:"allows to set verbosity of OpenTelemetry and gRPC internal loggers"`
+	Redact          string `env:"REDACT" default:"password,secret,*token,*_secret,api_key,authorization,cookie" usage:"allows to redact keys (- disables redaction)"`
+	Outputs         string `env:"OUTPUT_PATHS" default:"stderr" usage:"allows to set outputs (std, file or sink)" example:"stdout,app.log"`
+	ErrorOutputs    string `env:"ERROR_OUTPUT_PATHS" default:"stderr" usage:"allows to set outputs of logger internal errors"`
+	Development     bool   `env:"DEVELOPMENT" default:"false" usage:"allows to enable development mode (colored console, debug level)"`
+
+	Encoder EncoderConfig `env:"ENCODER"`
+	File    FileConfig    `env:"FILE"`
+	Async   AsyncConfig   `env:"ASYNC"`
+
+	Syslog   SyslogConfig   `env:"SYSLOG"`
+	Journald JournaldConfig `env:"JOURNALD"`
+	Report   ReportConfig   `env:"REPORT"`
+	Dedup    DedupConfig    `env:"DEDUP"`
+	Buffer   BufferConfig   `env:"BUFFER"`
 }
 
 type testingT interface {
@@ -29,24 +52,65 @@ type logger struct {
 	appName    string
 	appVersion string
 
-	colored bool
+	colored   bool
+	defaults  bool // outputs are not configured explicitly, so they are replaced by file, syslog or journald
+	verbosity int
 
-	config  zap.Config
-	options []zap.Option
+	config   zap.Config
+	levels   *Levels
+	redactor *redactor
+	files    []*fileSink
+	async    *asyncWriter
+	errs     zapcore.WriteSyncer // opened error outputs, internal errors of background goroutines are written into them
+	closer   func()
+	reporter *reporter
+	cores    []zapcore.Core
+	dedup    *deduplicator
+	every    *sync.Map
+	buffer   *Buffer
+	options  []zap.Option
+
+	span trace.SpanID // span, whose trace fields are already attached to the logger
+
+	optionErr error // error of applied options (e.g. invalid custom output)
 
 	*SugaredLogger
 }
 
 // Validate we should check that passed configuration is valid, so:
 // - trace and level should be empty or valid logger level
-// - sample rate should be empty or greater than zero.
+// - sample rate should be empty or greater than zero
+// - named levels should be empty or in `name=level,...` format
+// - verbosity should not be negative
+// - redacted keys should be valid glob patterns or regular expressions
+// - file rotation settings should not be negative
+// - async buffer size should be greater than zero, when async output is enabled
+// - syslog facility should be known
+// - report url should be valid (sentry DSN for sentry format)
+// - deduplication window should not be negative
+// - buffer size should not be negative.
 func (c *Config) Validate(_ context.Context) error {
 	err := validation.ValidateStruct(c,
 		validation.Field(&c.SampleRate, validation.NilOrNotEmpty),
-		validation.Field(&c.Level, validation.Required),
-		validation.Field(&c.Level, validation.Required, validation.In(allLevels...)),
-		validation.Field(&c.Trace, validation.Required),
-		validation.Field(&c.Trace, validation.Required, validation.In(allLevels...)))
+		validation.Field(&c.Level, validation.Required, enumRule("Level")),
+		validation.Field(&c.Trace, validation.Required, enumRule("Trace")),
+		validation.Field(&c.Levels, validation.By(func(interface{}) error {
+			_, errParse := parseNamedLevels(c.Levels)
+
+			return errParse
+		})),
+		validation.Field(&c.Verbosity, validation.Min(0)),
+		validation.Field(&c.Redact, validation.By(func(interface{}) error {
+			_, errParse := parseRedact(c.Redact)
+
+			return errParse
+		})),
+		validation.Field(&c.File),
+		validation.Field(&c.Async),
+		validation.Field(&c.Syslog),
+		validation.Field(&c.Report),
+		validation.Field(&c.Dedup),
+		validation.Field(&c.Buffer))
 	if err != nil {
 		return err
 	}
@@ -56,22 +120,64 @@ func (c *Config) Validate(_ context.Context) error {
 
 // With allows to provide zap.SugaredLogger as common interface.
 func (l *logger) With(args ...interface{}) Logger {
-	return &logger{
-		config:        l.config,
-		appName:       l.appName,
-		appVersion:    l.appVersion,
-		SugaredLogger: l.SugaredLogger.With(args...),
-	}
+	return l.child(l.SugaredLogger.With(args...))
 }
 
 // Named allows to set name for zap.SugaredLogger.
 func (l *logger) Named(name string) Logger {
+	return l.child(l.SugaredLogger.Named(name))
+}
+
+// child returns copy of the logger with passed zap.SugaredLogger.
+func (l *logger) child(sugar *SugaredLogger) *logger {
 	return &logger{
 		config:        l.config,
+		levels:        l.levels,
+		files:         l.files,
+		async:         l.async,
+		reporter:      l.reporter,
+		cores:         l.cores,
+		dedup:         l.dedup,
+		every:         l.every,
+		buffer:        l.buffer,
 		appName:       l.appName,
 		appVersion:    l.appVersion,
-		SugaredLogger: l.SugaredLogger.Named(name),
+		verbosity:     l.verbosity,
+		span:          l.span,
+		SugaredLogger: sugar,
+	}
+}
+
+// Levels allows to change logger level at runtime.
+func (l *logger) Levels() *Levels { return l.levels }
+
+// Buffer returns in-memory buffer of recent entries, it returns nil, when buffer is disabled.
+func (l *logger) Buffer() *Buffer { return l.buffer }
+
+// Reopen reopens file outputs, should be called when files were moved by logrotate (e.g. on SIGHUP).
+func (l *logger) Reopen() error {
+	var err error
+	for _, file := range l.files {
+		err = errors.Join(err, file.Reopen())
+	}
+
+	return err
+}
+
+// Close syncs logger and stops its background goroutines (e.g. deduplication, asynchronous output and error reporting),
+// logger still could be used after Close, but entries are written and reported synchronously without deduplication.
+func (l *logger) Close() error {
+	err := errors.Join(l.Sync(), l.dedup.stop())
+
+	if l.reporter != nil {
+		err = errors.Join(err, l.reporter.stop())
+	}
+
+	if l.async != nil {
+		l.async.stop()
 	}
+
+	return err
 }
 
 // Sugar returns zap.SugaredLogger.
@@ -80,15 +186,24 @@ func (l *logger) Sugar() *SugaredLogger { return l.SugaredLogger }
 // Std returns standard library log.Logger.
 func (l *logger) Std() *log.Logger { return zap.NewStdLog(l.Desugar()) }
 
-// nolint: gochecknoglobals
-var allLevels = []interface{}{
-	zapcore.InfoLevel.String(),
-	zapcore.DebugLevel.String(),
-	zapcore.WarnLevel.String(),
-	zapcore.ErrorLevel.String(),
-	zapcore.DPanicLevel.String(),
-	zapcore.PanicLevel.String(),
-	zapcore.FatalLevel.String(),
+// enumValues returns allowed values from enum tag of Config field, so they are declared once.
+func enumValues(name string) []interface{} {
+	field, _ := reflect.TypeOf(Config{}).FieldByName(name)
+
+	var out []interface{}
+	for _, item := range strings.Split(field.Tag.Get("enum"), ",") {
+		out = append(out, item)
+	}
+
+	return out
+}
+
+// enumRule checks that value is allowed by enum tag of Config field, error contains allowed values,
+// so they could be shown to user.
+func enumRule(name string) validation.Rule {
+	allowed := enumValues(name)
+
+	return validation.In(allowed...).ErrorObject(validation.ErrInInvalid.SetParams(map[string]interface{}{"allowed": allowed}))
 }
 
 // safeLevel converts string representation into log level.
@@ -114,6 +229,240 @@ func safeLevel(level string) zapcore.Level {
 // nolint: gochecknoglobals
 var defaultSampleRate = 1000
 
+// development returns configuration of development mode, that overrides encoding, level and trace level:
+// colored console output with short caller paths, debug level, stack traces on warn and panics on DPanic,
+// entries are not sampled.
+func (c Config) development() Config {
+	c.EncodingConsole = true
+	c.Encoding = EncodingConsole
+	c.Level = zapcore.DebugLevel.String()
+	c.Trace = zapcore.WarnLevel.String()
+
+	return c
+}
+
+// prepareEncoder sets encoding and encoder settings, could be overridden by options (e.g. WithTimeKey).
+func (l *logger) prepareEncoder(cfg Config) error {
+	l.config.Encoding = cfg.encoding()
+	if l.config.Encoding == EncodingLogfmt {
+		if err := registerLogfmt(); err != nil {
+			return err
+		}
+	}
+
+	cfg.Encoder.apply(&l.config.EncoderConfig)
+	l.config.DisableCaller = cfg.Encoder.DisableCaller
+
+	return nil
+}
+
+// prepareCores prepares runtime levels, redaction, deduplication, counters, file, syslog, journald and async outputs,
+// error reporting and buffer of recent entries, that are used by wrapped core.
+func (l *logger) prepareCores(cfg Config) error {
+	err := l.prepareLevels(cfg.Levels)
+	if err != nil {
+		return err
+	}
+
+	if l.errs, l.closer, err = zap.Open(l.config.ErrorOutputPaths...); err != nil {
+		return fmt.Errorf("could not open error outputs: %w", err)
+	}
+
+	if l.redactor, err = parseRedact(cfg.Redact); err != nil {
+		return err
+	}
+
+	l.dedup = newDeduplicator(cfg.Dedup, l.errs)
+
+	if err = registerMetrics(); err != nil {
+		return err
+	}
+
+	if err = l.attachFile(cfg.File); err != nil {
+		return err
+	}
+
+	if err = l.attachSyslog(cfg.Syslog, cfg.Journald); err != nil {
+		return err
+	}
+
+	if err = l.attachReport(cfg.Report); err != nil {
+		return err
+	}
+
+	if l.buffer = newBuffer(cfg.Buffer.Size); l.buffer != nil {
+		l.cores = append(l.cores, newBufferCore(l.buffer))
+	}
+
+	return l.attachAsync(cfg.Async)
+}
+
+// prepareLevels prepares runtime levels, custom level could be passed by WithCustomLevel.
+func (l *logger) prepareLevels(levels string) error {
+	named, err := parseNamedLevels(levels)
+	if err != nil {
+		return err
+	}
+
+	l.levels = newLevels(l.config.Level)
+	for name, lvl := range named {
+		l.levels.SetNamedLevel(name, lvl, 0)
+	}
+
+	return nil
+}
+
+// wrapCore wraps core that writes entries with redaction, counters, sampling, deduplication and runtime levels,
+// syslog, journald, report and buffer cores are teed with it (core is omitted when there are no outputs).
+// Deduplication is applied before sampling, so `repeated` counts all collapsed entries.
+func (l *logger) wrapCore(core zapcore.Core) zapcore.Core {
+	if len(l.cores) > 0 {
+		cores := l.cores
+		if len(l.config.OutputPaths) > 0 {
+			cores = append([]zapcore.Core{core}, cores...)
+		}
+
+		core = zapcore.NewTee(cores...)
+	}
+
+	core = zapcore.RegisterHooks(l.redactor.wrapCore(core), countEntry)
+
+	if sampling := l.config.Sampling; sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter,
+			zapcore.SamplerHook(countSampled))
+	}
+
+	return l.levels.wrapCore(l.dedup.wrapCore(core))
+}
+
+// attachFile replaces standard outputs with rotated file, when file path is passed.
+func (l *logger) attachFile(cfg FileConfig) error {
+	if cfg.Path == "" {
+		return nil
+	}
+
+	sink, err := newFileSink(cfg, l.errs)
+	if err != nil {
+		return err
+	}
+
+	path, err := registerSink(sink)
+	if err != nil {
+		_ = sink.Close()
+
+		return err
+	}
+
+	l.files = append(l.files, sink)
+	l.config.OutputPaths = append(l.explicitOutputs(), path)
+
+	return nil
+}
+
+// attachSyslog replaces default outputs with syslog and journald, when they are enabled.
+func (l *logger) attachSyslog(sys SyslogConfig, journal JournaldConfig) error {
+	if sys.Address != "" {
+		cfg := l.config.EncoderConfig
+		if l.colored {
+			cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		}
+
+		core, err := newSyslogCore(sys, newEncoder(l.config.Encoding, cfg), l.appName)
+		if err != nil {
+			return err
+		}
+
+		l.cores = append(l.cores, core)
+	}
+
+	if journal.Enabled {
+		l.cores = append(l.cores, newJournaldCore(journal, l.appName))
+	}
+
+	if len(l.cores) > 0 {
+		l.config.OutputPaths = l.explicitOutputs()
+	}
+
+	return nil
+}
+
+// attachReport adds core, that reports errors, when report url is passed.
+func (l *logger) attachReport(cfg ReportConfig) error {
+	send, err := newReportSender(cfg)
+	if err != nil || send == nil {
+		return err
+	}
+
+	level := zapcore.ErrorLevel
+	if cfg.Level != "" {
+		level = safeLevel(cfg.Level)
+	}
+
+	l.reporter = newReporter(cfg, send, l.appName, l.appVersion, l.errs)
+	l.cores = append(l.cores, newReportCore(level, l.reporter))
+
+	return nil
+}
+
+// release stops background goroutines and closes outputs, when logger could not be created.
+func (l *logger) release() {
+	_ = l.dedup.stop()
+
+	if l.reporter != nil {
+		_ = l.reporter.stop()
+	}
+
+	if l.async != nil {
+		_ = l.async.Close()
+	}
+
+	for _, file := range l.files {
+		_ = file.Close()
+	}
+
+	if l.closer != nil {
+		l.closer()
+	}
+}
+
+// explicitOutputs returns explicitly configured outputs, default outputs are dropped,
+// because file, syslog and journald outputs replace them.
+func (l *logger) explicitOutputs() []string {
+	if !l.defaults {
+		return l.config.OutputPaths
+	}
+
+	l.defaults = false
+
+	return make([]string, 0, 1)
+}
+
+// attachAsync replaces outputs with asynchronous buffered writer, when it is enabled.
+func (l *logger) attachAsync(cfg AsyncConfig) error {
+	if !cfg.Enabled || len(l.config.OutputPaths) == 0 {
+		return nil
+	}
+
+	out, closer, err := zap.Open(l.config.OutputPaths...)
+	if err != nil {
+		return err
+	}
+
+	writer := newAsyncWriter(out, closer, cfg, l.errs)
+
+	path, err := registerSink(writer)
+	if err != nil {
+		_ = writer.Close()
+
+		return err
+	}
+
+	l.async = writer
+	l.config.OutputPaths = []string{path}
+
+	return nil
+}
+
 // Default returns default logger instance.
 func Default() Logger {
 	atom := zap.NewAtomicLevel()
@@ -127,45 +476,68 @@ func Default() Logger {
 	// Default JSON encoder
 	encoder := zapcore.NewJSONEncoder(encoderCfg)
 
-	l := zap.New(zapcore.NewCore(
+	levels := newLevels(atom)
+	redact, _ := parseRedact(defaultRedact)
+
+	l := zap.New(levels.wrapCore(redact.wrapCore(zapcore.NewCore(
 		encoder,
 		zapcore.Lock(os.Stdout),
-		atom),
+		zapcore.DebugLevel))),
 		zap.AddCaller(),
 	)
 
-	return &logger{SugaredLogger: l.Sugar()}
+	return &logger{levels: levels, every: new(sync.Map), SugaredLogger: l.Sugar()}
 }
 
 // ForTests wrapped logger for tests.
 func ForTests(t testingT) Logger {
 	t.Helper()
 
-	return &logger{SugaredLogger: zaptest.NewLogger(t).Sugar()}
+	levels := newLevels(zap.NewAtomicLevelAt(zapcore.DebugLevel))
+
+	return &logger{levels: levels, every: new(sync.Map), SugaredLogger: zaptest.NewLogger(t,
+		zaptest.WrapOptions(zap.WrapCore(levels.wrapCore))).Sugar()}
 }
 
 // New prepares logger module.
 func New(cfg Config, opts ...Option) (Logger, error) {
 	var err error
+	if cfg.Development {
+		cfg = cfg.development()
+	}
+
 	logLevel := safeLevel(cfg.Level)
 	logTrace := safeLevel(cfg.Trace)
 
-	var l logger
+	l := logger{every: new(sync.Map), colored: cfg.Development}
 	l.config = zap.NewProductionConfig()
 
 	l.config.Level = zap.NewAtomicLevelAt(logLevel)
+	l.config.Development = cfg.Development
+
+	if paths := outputPaths(cfg.Outputs); len(paths) > 0 {
+		l.config.OutputPaths = paths
+	}
 
-	l.config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	l.defaults = cfg.Outputs == "" || cfg.Outputs == defaultOutputs
 
-	if cfg.EncodingConsole {
-		l.config.Encoding = "console"
+	if paths := outputPaths(cfg.ErrorOutputs); len(paths) > 0 {
+		l.config.ErrorOutputPaths = paths
+	}
+
+	if err = l.prepareEncoder(cfg); err != nil {
+		return nil, err
 	}
 
 	for _, o := range opts {
 		o(&l)
 	}
 
-	if cfg.EncodingConsole && l.colored {
+	if l.optionErr != nil {
+		return nil, l.optionErr
+	}
+
+	if l.config.Encoding == EncodingConsole && l.colored {
 		l.config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
 
@@ -176,8 +548,29 @@ func New(cfg Config, opts ...Option) (Logger, error) {
 	l.config.Sampling.Initial = *cfg.SampleRate
 	l.config.Sampling.Thereafter = *cfg.SampleRate
 
+	if cfg.Development {
+		l.config.Sampling = nil
+	}
+
+	l.verbosity = cfg.Verbosity
+
+	if err = l.prepareCores(cfg); err != nil {
+		l.release()
+
+		return nil, err
+	}
+
+	// levels are checked by wrapped core, so underlying core should enable all of them,
+	// sampling is applied by wrapped core too, so it could be placed after redaction
+	build := l.config
+	build.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	build.Sampling = nil
+	build.ErrorOutputPaths = nil // error outputs are already opened and passed by zap.ErrorOutput
+
 	var zapLogger *zap.Logger
-	if zapLogger, err = l.config.Build(zap.AddStacktrace(logTrace)); err != nil {
+	if zapLogger, err = build.Build(zap.AddStacktrace(logTrace), zap.ErrorOutput(l.errs), zap.WrapCore(l.wrapCore)); err != nil {
+		l.release()
+
 		return nil, err
 	}
 
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -60,12 +62,119 @@ func TestSugaredLogger(t *testing.T) {
 }
 
 func TestWithCustomOutput(t *testing.T) {
-	require.Panics(t, func() {
-		_, err := New(Config{}, WithCustomOutput("unsupported_format", &fakeSink{Writer: io.Discard}))
-		require.NoError(t, err)
+	t.Run("should allow to register output again", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			buf := new(bytes.Buffer)
+			log, err := New(Config{Level: "info", Trace: "fatal"}, WithCustomOutput("repeated_output", &fakeSink{Writer: buf}))
+			require.NoError(t, err)
+
+			log.Info("hello world")
+			require.Contains(t, buf.String(), "hello world")
+		}
+	})
+
+	t.Run("should fail on invalid output", func(t *testing.T) {
+		_, err := New(Config{}, WithCustomOutput("", &fakeSink{Writer: io.Discard}))
+		require.EqualError(t, err, `could not register custom output: invalid sink name ""`)
+
+		_, err = New(Config{}, WithCustomOutput("42", &fakeSink{Writer: io.Discard}))
+		require.EqualError(t, err, `could not register custom output: invalid sink name "42"`)
+
+		_, err = New(Config{}, WithCustomOutput("nil-output", nil))
+		require.EqualError(t, err, `could not register custom output: sink should not be nil`)
 	})
 }
 
+type failedWriter struct{}
+
+func (failedWriter) Write([]byte) (int, error) { return 0, errors.New("failed writer") }
+
+func TestNew_OutputPaths(t *testing.T) {
+	out, errs := new(bytes.Buffer), new(bytes.Buffer)
+
+	_, err := RegisterSink("output-paths-test", &fakeSink{Writer: out})
+	require.NoError(t, err)
+
+	_, err = RegisterSink("error-output-paths-test", &fakeSink{Writer: errs})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := New(Config{Level: "info", Trace: "fatal", Outputs: "output-paths-test, " + path, ErrorOutputs: "error-output-paths-test"})
+	require.NoError(t, err)
+
+	log.Info("hello world")
+	require.NoError(t, log.Sync())
+	require.Contains(t, out.String(), "hello world")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "hello world")
+
+	// internal errors (e.g. write errors) are written into error outputs
+	log, err = New(Config{Level: "info", Trace: "fatal", ErrorOutputs: "error-output-paths-test"},
+		WithCustomOutput("failed-output-paths-test", &fakeSink{Writer: failedWriter{}}))
+	require.NoError(t, err)
+
+	log.Info("could not be written")
+	require.Contains(t, errs.String(), "write error: failed writer")
+
+	// internal errors of background goroutines are written into error outputs too
+	errs.Reset()
+	log, err = New(Config{Level: "info", Trace: "fatal", ErrorOutputs: "error-output-paths-test", Async: AsyncConfig{Enabled: true, BufferSize: 1}},
+		WithCustomOutput("failed-async-output-paths-test", &fakeSink{Writer: failedWriter{}}))
+	require.NoError(t, err)
+
+	log.Info("could not be written asynchronously")
+	require.Error(t, log.Close())
+	require.Contains(t, errs.String(), "could not write logs asynchronously: failed writer")
+
+	_, err = New(Config{Level: "info", Trace: "fatal", Outputs: "unknown-sink:test"})
+	require.ErrorContains(t, err, `no sink found for scheme "unknown-sink"`)
+}
+
+func TestNew_ExplicitOutputs(t *testing.T) {
+	dir := t.TempDir()
+
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	require.NoError(t, err)
+
+	defer func(v *os.File) { os.Stdout = v }(os.Stdout)
+
+	os.Stdout = stdout
+
+	// explicit outputs are kept, file output is added next to them
+	path := filepath.Join(dir, "app.log")
+	log, err := New(Config{Level: "info", Trace: "fatal", Outputs: "stdout", File: FileConfig{Path: path}})
+	require.NoError(t, err)
+
+	log.Info("hello world")
+	require.NoError(t, log.Sync())
+
+	for _, name := range []string{stdout.Name(), path} {
+		data, errRead := os.ReadFile(name)
+		require.NoError(t, errRead)
+		require.Contains(t, string(data), "hello world")
+	}
+
+	// default outputs are replaced by file and syslog outputs
+	for _, outputs := range []string{"", defaultOutputs} {
+		log, err = New(Config{
+			Level:   "info",
+			Trace:   "fatal",
+			Outputs: outputs,
+			File:    FileConfig{Path: filepath.Join(dir, "default.log")},
+			Syslog:  SyslogConfig{Address: "127.0.0.1:1"},
+		})
+		require.NoError(t, err)
+		require.Len(t, log.(*logger).config.OutputPaths, 1)
+		require.NotContains(t, log.(*logger).config.OutputPaths, defaultOutputs)
+	}
+
+	log, err = New(Config{Level: "info", Trace: "fatal", Outputs: "stdout", Syslog: SyslogConfig{Address: "127.0.0.1:1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"stdout"}, log.(*logger).config.OutputPaths)
+}
+
 func TestConfig_Validate(t *testing.T) {
 	var empty int
 	cases := []struct {
@@ -116,7 +225,8 @@ func TestConfig_Validate(t *testing.T) {
 			error: validation.Errors{
 				"Level": (validation.ErrorObject{}).
 					SetCode("validation_in_invalid").
-					SetMessage("must be a valid value"),
+					SetMessage("must be a valid value").
+					SetParams(map[string]interface{}{"allowed": enumValues("Level")}),
 			},
 		},
 		{
@@ -129,7 +239,32 @@ func TestConfig_Validate(t *testing.T) {
 			error: validation.Errors{
 				"Trace": (validation.ErrorObject{}).
 					SetCode("validation_in_invalid").
-					SetMessage("must be a valid value"),
+					SetMessage("must be a valid value").
+					SetParams(map[string]interface{}{"allowed": enumValues("Trace")}),
+			},
+		},
+		{
+			name: "fail for empty level value",
+			config: Config{
+				Trace:      zapcore.FatalLevel.String(),
+				SampleRate: &defaultSampleRate,
+			},
+			error: validation.Errors{
+				"Level": (validation.ErrorObject{}).
+					SetCode("validation_required").
+					SetMessage("cannot be blank"),
+			},
+		},
+		{
+			name: "fail for empty trace level value",
+			config: Config{
+				Level:      zapcore.FatalLevel.String(),
+				SampleRate: &defaultSampleRate,
+			},
+			error: validation.Errors{
+				"Trace": (validation.ErrorObject{}).
+					SetCode("validation_required").
+					SetMessage("cannot be blank"),
 			},
 		},
 	}
@@ -193,6 +328,42 @@ func TestNew(t *testing.T) {
 			},
 		},
 
+		{
+			name: "should use custom encoder settings",
+			config: Config{
+				Level: zapcore.InfoLevel.String(),
+				Trace: zapcore.FatalLevel.String(),
+				Encoder: EncoderConfig{
+					MessageKey:    "message",
+					LevelKey:      "severity",
+					NameKey:       "component",
+					TimeKey:       omitKey,
+					LevelCase:     "upper",
+					DisableCaller: true,
+				},
+			},
+
+			output: []string{
+				`{"severity":"ERROR","message":"hello world","error":"test-error","key":"val"}`,
+				`{"severity":"INFO","component":"custom-name","message":"custom logger info message"}`,
+			},
+		},
+
+		{
+			name: "should use logfmt encoding",
+			config: Config{
+				Level:    zapcore.InfoLevel.String(),
+				Trace:    zapcore.FatalLevel.String(),
+				Encoding: EncodingLogfmt,
+				Encoder:  EncoderConfig{TimeFormat: "epoch", DisableCaller: true},
+			},
+
+			output: []string{
+				`level=error msg="hello world" error=test-error key=val`,
+				`level=info logger=custom-name msg="custom logger info message"`,
+			},
+		},
+
 		{
 			name: "should fail on build logger",
 			config: Config{
@@ -257,3 +428,31 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_Development(t *testing.T) {
+	buf := new(bytes.Buffer)
+	log, err := New(Config{Level: "error", Trace: "fatal", Encoding: EncodingJSON, Development: true},
+		WithCustomOutput("development-test", &fakeSink{Writer: buf}))
+	require.NoError(t, err)
+	require.Equal(t, zapcore.DebugLevel, log.Levels().Level())
+
+	log.Debug("debug message")
+	require.Contains(t, buf.String(), "\x1b[35mDEBUG\x1b[0m")
+	require.Contains(t, buf.String(), "logger/logger_test.go:")
+	require.NotContains(t, buf.String(), "go-bones/logger/logger_test.go:")
+	require.NotContains(t, buf.String(), `"msg"`)
+
+	buf.Reset()
+	log.Warn("warn message")
+	require.Contains(t, buf.String(), "TestNew_Development")
+
+	// entries are not sampled
+	buf.Reset()
+	for i := 0; i < defaultSampleRate*2; i++ {
+		log.Info("repeated message")
+	}
+
+	require.Equal(t, defaultSampleRate*2, bytes.Count(buf.Bytes(), []byte("repeated message")))
+
+	require.Panics(t, func() { log.Sugar().DPanic("should panic") })
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logrSink implements logr.LogSink over SugaredLogger.
+type logrSink struct {
+	log       *SugaredLogger
+	levels    *Levels
+	verbosity int
+}
+
+var (
+	_ logr.LogSink          = (*logrSink)(nil)
+	_ logr.CallDepthLogSink = (*logrSink)(nil)
+)
+
+// Logr returns logr.Logger (e.g. for otel.SetLogger), V-levels greater than configured verbosity are dropped,
+// V(0) is written with info level and others with debug level.
+func (l *logger) Logr() logr.Logger {
+	return logr.New(&logrSink{log: l.Sugar(), levels: l.levels, verbosity: l.verbosity})
+}
+
+// logrLevel converts logr V-level into zapcore.Level.
+func logrLevel(level int) zapcore.Level {
+	if level > 0 {
+		return zapcore.DebugLevel
+	}
+
+	return zapcore.InfoLevel
+}
+
+// Init skips logr.Logger frames, so caller points to the code that uses logr.Logger.
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.log = s.log.WithOptions(zap.AddCallerSkip(info.CallDepth + 1))
+}
+
+// Enabled reports whether V-level is not greater than verbosity and enabled by logger,
+// named levels (e.g. `LOGGER_LEVELS=otel=warn`) are checked by the logger name.
+func (s *logrSink) Enabled(level int) bool {
+	if level > s.verbosity {
+		return false
+	}
+
+	log, lvl := s.log.Desugar(), logrLevel(level)
+	if s.levels != nil && !s.levels.enabled(log.Name(), lvl) {
+		return false
+	}
+
+	return log.Core().Enabled(lvl)
+}
+
+// Info writes message with info level for V(0) and debug level for others.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if logrLevel(level) == zapcore.InfoLevel {
+		s.log.Infow(msg, keysAndValues...)
+
+		return
+	}
+
+	s.log.Debugw(msg, keysAndValues...)
+}
+
+// Error writes message and error with error level, it does not depend on verbosity.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.log.Errorw(msg, append([]interface{}{"error", err}, keysAndValues...)...)
+}
+
+// WithValues returns sink with passed fields.
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &logrSink{log: s.log.With(keysAndValues...), levels: s.levels, verbosity: s.verbosity}
+}
+
+// WithName returns sink with passed name.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{log: s.log.Named(name), levels: s.levels, verbosity: s.verbosity}
+}
+
+// WithCallDepth returns sink that skips additional frames for caller.
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	return &logrSink{log: s.log.WithOptions(zap.AddCallerSkip(depth)), levels: s.levels, verbosity: s.verbosity}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogr(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := &logger{verbosity: 1, SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()}
+
+	t.Run("should map verbosity levels", func(t *testing.T) {
+		out := log.Logr()
+		require.True(t, out.Enabled())
+		require.True(t, out.V(1).Enabled())
+		require.False(t, out.V(2).Enabled())
+
+		out.Info("info", "key", "val")
+		out.V(1).Info("debug")
+		out.V(2).Info("skipped")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+
+		require.Equal(t, zapcore.InfoLevel, entries[0].Level)
+		require.Equal(t, "info", entries[0].Message)
+		require.Equal(t, "logr_test.go", filepath.Base(entries[0].Caller.File))
+		require.Equal(t, map[string]interface{}{"key": "val"}, entries[0].ContextMap())
+
+		require.Equal(t, zapcore.DebugLevel, entries[1].Level)
+		require.Equal(t, "debug", entries[1].Message)
+	})
+
+	t.Run("should write errors regardless of verbosity", func(t *testing.T) {
+		log.Logr().V(5).Error(errors.New("boom"), "failed", "key", "val")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		require.Equal(t, map[string]interface{}{"error": "boom", "key": "val"}, entries[0].ContextMap())
+	})
+
+	t.Run("should keep names, values and call depth", func(t *testing.T) {
+		helper := func(msg string) { log.Logr().WithName("otel").WithValues("key", "val").WithCallDepth(1).Info(msg) }
+
+		helper("named")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, "otel", entries[0].LoggerName)
+		require.Equal(t, "logr_test.go", filepath.Base(entries[0].Caller.File))
+		require.Equal(t, map[string]interface{}{"key": "val"}, entries[0].ContextMap())
+	})
+
+	t.Run("should respect logger level", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+		out := (&logger{verbosity: 1, SugaredLogger: zap.New(levels.wrapCore(core)).Sugar()}).Logr()
+
+		require.True(t, out.Enabled())
+		require.False(t, out.V(1).Enabled())
+	})
+
+	t.Run("should respect named levels", func(t *testing.T) {
+		levels := newLevels(zap.NewAtomicLevelAt(zapcore.DebugLevel))
+		levels.SetNamedLevel("otel", zapcore.WarnLevel, 0)
+
+		out := (&logger{verbosity: 1, levels: levels, SugaredLogger: zap.New(levels.wrapCore(core)).Sugar()}).Logr()
+
+		require.True(t, out.V(1).Enabled())
+		require.False(t, out.WithName("otel").Enabled())
+		require.False(t, out.WithName("otel").WithName("sdk").V(1).Enabled())
+		require.True(t, out.WithName("grpc").V(1).Enabled())
+	})
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/zapcore"
+)
+
+// nolint: gochecknoglobals
+var (
+	logMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_messages_total",
+		Help: "Count of written log entries by level and logger name.",
+	}, []string{"level", "logger"})
+
+	logDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "log_messages_dropped_total",
+		Help: "Count of log entries dropped by sampling by level and logger name.",
+	}, []string{"level", "logger"})
+
+	metricsOnce sync.Once
+	metricsErr  error
+)
+
+// registerMetrics registers logger counters in default prometheus registry, so they are served by ops server.
+func registerMetrics() error {
+	metricsOnce.Do(func() {
+		for _, collector := range []prometheus.Collector{logMessages, logDropped, asyncDropped, reportSent, reportDropped} {
+			if err := prometheus.Register(collector); err != nil && !errors.As(err, new(prometheus.AlreadyRegisteredError)) {
+				metricsErr = err
+
+				return
+			}
+		}
+	})
+
+	return metricsErr
+}
+
+// countEntry increments written entries counter.
+func countEntry(ent zapcore.Entry) error {
+	logMessages.WithLabelValues(ent.Level.String(), ent.LoggerName).Inc()
+
+	return nil
+}
+
+// countSampled increments dropped entries counter, when sampler drops entry.
+func countSampled(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+	if dec&zapcore.LogDropped != 0 {
+		logDropped.WithLabelValues(ent.Level.String(), ent.LoggerName).Inc()
+	}
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	rate := 2
+	log, err := New(Config{Level: "info", Trace: "fatal", SampleRate: &rate},
+		WithCustomOutput("metrics-test", &fakeSink{Writer: io.Discard}))
+	require.NoError(t, err)
+
+	written := logMessages.WithLabelValues("warn", "metrics")
+	dropped := logDropped.WithLabelValues("warn", "metrics")
+	skipped := logMessages.WithLabelValues("debug", "metrics")
+
+	before := []float64{testutil.ToFloat64(written), testutil.ToFloat64(dropped), testutil.ToFloat64(skipped)}
+
+	named := log.Named("metrics")
+	for i := 0; i < 5; i++ {
+		named.Warn("sampled message")
+		named.Debug("disabled message")
+	}
+
+	// first 2 entries are written, thereafter every 2nd entry is written
+	require.Equal(t, float64(3), testutil.ToFloat64(written)-before[0])
+	require.Equal(t, float64(2), testutil.ToFloat64(dropped)-before[1])
+	require.Equal(t, float64(0), testutil.ToFloat64(skipped)-before[2])
+
+	t.Run("should be registered in default registry", func(t *testing.T) {
+		families, errGather := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, errGather)
+
+		names := make([]string, 0, len(families))
+		for _, family := range families {
+			names = append(names, family.GetName())
+		}
+
+		require.Contains(t, names, "log_messages_total")
+		require.Contains(t, names, "log_messages_dropped_total")
+	})
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// LoggedEntry is an entry recorded by Observed logger.
+// Type alias.
+type LoggedEntry = observer.LoggedEntry
+
+// Observed is a logger for tests, that records entries (message, level, fields and logger name)
+// and still writes them to t.Log. Child loggers (With, Named) record entries into the same storage.
+type Observed struct {
+	Logger
+
+	logs *observer.ObservedLogs
+}
+
+// ForTestsObserved returns logger for tests, that allows to assert logged entries.
+func ForTestsObserved(t testingT) *Observed {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	levels := newLevels(zap.NewAtomicLevelAt(zapcore.DebugLevel))
+
+	return &Observed{
+		logs: logs,
+		Logger: &logger{levels: levels, every: new(sync.Map), SugaredLogger: zaptest.NewLogger(t,
+			zaptest.WrapOptions(zap.WrapCore(func(out zapcore.Core) zapcore.Core {
+				return levels.wrapCore(zapcore.NewTee(out, core))
+			}))).Sugar()},
+	}
+}
+
+// All returns all recorded entries.
+func (o *Observed) All() []LoggedEntry { return o.logs.All() }
+
+// TakeAll returns all recorded entries and removes them.
+func (o *Observed) TakeAll() []LoggedEntry { return o.logs.TakeAll() }
+
+// Len returns count of recorded entries.
+func (o *Observed) Len() int { return o.logs.Len() }
+
+// FilterMessage returns recorded entries with passed message.
+func (o *Observed) FilterMessage(msg string) []LoggedEntry { return o.logs.FilterMessage(msg).All() }
+
+// FilterLevel returns recorded entries with passed level.
+func (o *Observed) FilterLevel(level zapcore.Level) []LoggedEntry {
+	return o.logs.Filter(func(e LoggedEntry) bool { return e.Level == level }).All()
+}
+
+// AssertLogged checks that entry with passed level and message was recorded and it contains passed key/value pairs,
+// values are compared in the same way as they are logged (e.g. errors are compared by message).
+func (o *Observed) AssertLogged(t testingT, level zapcore.Level, msg string, keysAndValues ...interface{}) bool {
+	t.Helper()
+
+	expect := zapcore.NewMapObjectEncoder()
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{}
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+
+		zap.Any(fmt.Sprint(keysAndValues[i]), value).AddTo(expect)
+	}
+
+	entries := o.logs.All()
+	for _, entry := range entries {
+		if entry.Level != level || entry.Message != msg {
+			continue
+		}
+
+		if containsFields(entry.ContextMap(), expect.Fields) {
+			return true
+		}
+	}
+
+	var out strings.Builder
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(&out, "\n\t%s %s %q %v", entry.Level, entry.LoggerName, entry.Message, entry.ContextMap())
+	}
+
+	t.Errorf("expected %s entry %q with fields %v, but it was not logged, recorded entries:%s",
+		level, msg, expect.Fields, out.String())
+
+	return false
+}
+
+func containsFields(actual, expect map[string]interface{}) bool {
+	for key, value := range expect {
+		if current, ok := actual[key]; !ok || !reflect.DeepEqual(current, value) {
+			return false
+		}
+	}
+
+	return true
+}
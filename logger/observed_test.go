@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type capturedErrors struct {
+	*testing.T
+
+	errors []string
+}
+
+func (c *capturedErrors) Errorf(format string, args ...interface{}) {
+	c.errors = append(c.errors, fmt.Sprintf(format, args...))
+}
+
+func TestForTestsObserved(t *testing.T) {
+	log := ForTestsObserved(t)
+
+	log.Infow("started", "service", "api", "port", 8080)
+	log.Named("worker").With("id", 1).Errorw("failed", "error", errors.New("boom"))
+	log.Debug("debug message")
+
+	t.Run("should record entries", func(t *testing.T) {
+		require.Equal(t, 3, log.Len())
+		require.Len(t, log.All(), 3)
+
+		entries := log.FilterMessage("failed")
+		require.Len(t, entries, 1)
+		require.Equal(t, "worker", entries[0].LoggerName)
+		require.Equal(t, map[string]interface{}{"id": int64(1), "error": "boom"}, entries[0].ContextMap())
+
+		require.Len(t, log.FilterLevel(zapcore.DebugLevel), 1)
+	})
+
+	t.Run("should assert logged entries", func(t *testing.T) {
+		require.True(t, log.AssertLogged(t, zapcore.InfoLevel, "started"))
+		require.True(t, log.AssertLogged(t, zapcore.InfoLevel, "started", "port", 8080))
+		require.True(t, log.AssertLogged(t, zapcore.ErrorLevel, "failed", "error", errors.New("boom"), "id", 1))
+	})
+
+	t.Run("should fail when entry was not logged", func(t *testing.T) {
+		out := &capturedErrors{T: t}
+
+		require.False(t, log.AssertLogged(out, zapcore.WarnLevel, "started"))
+		require.False(t, log.AssertLogged(out, zapcore.InfoLevel, "started", "port", 8081))
+		require.False(t, log.AssertLogged(out, zapcore.InfoLevel, "unknown"))
+
+		require.Len(t, out.errors, 3)
+		require.Contains(t, out.errors[1], `expected info entry "started" with fields map[port:8081]`)
+		require.Contains(t, out.errors[1], `info  "started" map[port:8080 service:api]`)
+	})
+
+	t.Run("should take all entries", func(t *testing.T) {
+		require.Len(t, log.TakeAll(), 3)
+		require.Zero(t, log.Len())
+	})
+}
@@ -1,8 +1,8 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
-	"net/url"
 
 	"go.uber.org/zap"
 )
@@ -41,17 +41,18 @@ func WithCustomLevel(v zap.AtomicLevel) Option {
 	return func(l *logger) { l.config.Level = v }
 }
 
-// WithCustomOutput allows to set custom output for Logger.
+// WithCustomOutput allows to set custom output for Logger, sink is registered by RegisterSink,
+// so the same name could be passed again (e.g. in tests), logger.New returns error, when sink is invalid.
 func WithCustomOutput(name string, v Sink) Option {
 	return func(l *logger) {
-		scheme := name + "-writer"
+		path, err := RegisterSink(name, v)
+		if err != nil {
+			l.optionErr = errors.Join(l.optionErr, fmt.Errorf("could not register custom output: %w", err))
 
-		if err := zap.RegisterSink(scheme, func(_ *url.URL) (Sink, error) { return v, nil }); err != nil {
-			Default().Panicf("could not register custom logger.Sink: %s", err)
+			return
 		}
 
-		scheme = fmt.Sprintf("%s:whatever", scheme)
-
-		l.config.OutputPaths = []string{scheme}
+		l.config.OutputPaths = []string{path}
+		l.defaults = false
 	}
 }
@@ -0,0 +1,326 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue replaces values of redacted keys.
+const RedactedValue = "[REDACTED]"
+
+// RedactDisabled disables redaction, when it is passed as Config.Redact value.
+const RedactDisabled = "-"
+
+// defaultRedact is used, when Config.Redact is empty, and should be in sync with Config.Redact default value.
+const defaultRedact = "password,secret,*token,*_secret,api_key,authorization,cookie"
+
+type (
+	// redactor matches keys (case-insensitive) by exact name, glob pattern or regular expression.
+	redactor struct {
+		exact   map[string]struct{}
+		globs   []string
+		regexps []*regexp.Regexp
+
+		types sync.Map // reflect.Type -> bool, whether values of the type could contain redacted keys
+	}
+
+	// redactCore replaces values of matched keys in fields and nested objects,
+	// redacted entry is written through Check of the wrapped core, so levels of teed cores are respected.
+	redactCore struct {
+		zapcore.Core
+
+		redactor *redactor
+	}
+
+	// writeErrors collects write errors, that zap reports to ErrorOutput of checked entry.
+	writeErrors struct{ err error }
+)
+
+// nolint: gochecknoglobals
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// parseRedact parses comma separated list of keys, where each item is exact key,
+// glob pattern (e.g. `*_token`) or regular expression wrapped into slashes (e.g. `/^x-.+-key$/`).
+// Default keys are used when list is empty, it returns nil when redaction is disabled.
+func parseRedact(v string) (*redactor, error) {
+	switch strings.Trim(v, " ,") {
+	case RedactDisabled:
+		return nil, nil
+	case "":
+		v = defaultRedact
+	}
+
+	out := &redactor{exact: make(map[string]struct{})}
+
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		switch {
+		case len(item) > 2 && strings.HasPrefix(item, "/") && strings.HasSuffix(item, "/"):
+			re, err := regexp.Compile("(?i)" + item[1:len(item)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid redact pattern %q: %w", item, err)
+			}
+
+			out.regexps = append(out.regexps, re)
+		case strings.ContainsAny(item, "*?["):
+			item = strings.ToLower(item)
+			if _, err := path.Match(item, ""); err != nil {
+				return nil, fmt.Errorf("invalid redact pattern %q: %w", item, err)
+			}
+
+			out.globs = append(out.globs, item)
+		default:
+			out.exact[strings.ToLower(item)] = struct{}{}
+		}
+	}
+
+	if len(out.exact) == 0 && len(out.globs) == 0 && len(out.regexps) == 0 {
+		return nil, nil
+	}
+
+	return out, nil
+}
+
+// match reports whether value of the key should be redacted.
+func (r *redactor) match(key string) bool {
+	lower := strings.ToLower(key)
+	if _, ok := r.exact[lower]; ok {
+		return true
+	}
+
+	for _, glob := range r.globs {
+		if ok, _ := path.Match(glob, lower); ok {
+			return true
+		}
+	}
+
+	for _, re := range r.regexps {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wrapCore returns core that redacts fields, when redactor is not empty.
+func (r *redactor) wrapCore(core zapcore.Core) zapcore.Core {
+	if r == nil {
+		return core
+	}
+
+	return &redactCore{Core: core, redactor: r}
+}
+
+// fields returns copy of fields with redacted values, when something should be redacted.
+func (r *redactor) fields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i := range fields {
+		field, ok := r.field(fields[i])
+		if !ok {
+			continue
+		}
+
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+
+		out[i] = field
+	}
+
+	if out == nil {
+		return fields
+	}
+
+	return out
+}
+
+// field redacts field value or nested keys of objects, arrays and reflected values.
+func (r *redactor) field(field zapcore.Field) (zapcore.Field, bool) {
+	switch {
+	case field.Type == zapcore.NamespaceType || field.Type == zapcore.SkipType:
+		return field, false
+	case r.match(field.Key):
+		return zap.String(field.Key, RedactedValue), true
+	}
+
+	var value interface{}
+	switch field.Type {
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+
+		value = enc.Fields[field.Key]
+	case zapcore.ReflectType:
+		if field.Interface == nil || !r.reflected(reflect.TypeOf(field.Interface)) {
+			return field, false
+		}
+
+		data, err := json.Marshal(field.Interface)
+		if err != nil {
+			return field, false
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+
+		if err = dec.Decode(&value); err != nil {
+			return field, false
+		}
+	default:
+		return field, false
+	}
+
+	if value, ok := r.value(value); ok {
+		return zap.Any(field.Key, value), true
+	}
+
+	return field, false
+}
+
+// reflected reports whether values of the type could contain redacted keys, result is cached per type,
+// so reflected values are not marshaled on every write, when their types could not contain redacted keys.
+func (r *redactor) reflected(typ reflect.Type) bool {
+	if cached, ok := r.types.Load(typ); ok {
+		out, _ := cached.(bool)
+
+		return out
+	}
+
+	out := r.walkType(typ, make(map[reflect.Type]struct{}))
+	r.types.Store(typ, out)
+
+	return out
+}
+
+// walkType checks JSON keys of struct fields, maps, interfaces and custom marshalers could contain any key.
+func (r *redactor) walkType(typ reflect.Type, seen map[reflect.Type]struct{}) bool {
+	if _, ok := seen[typ]; ok {
+		return false
+	}
+
+	seen[typ] = struct{}{}
+
+	if typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonMarshalerType) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface, reflect.Map:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return r.walkType(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			} else if name == "" {
+				name = field.Name
+			}
+
+			if r.match(name) || r.walkType(field.Type, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// value redacts keys of nested maps and slices.
+func (r *redactor) value(value interface{}) (interface{}, bool) {
+	var changed bool
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if r.match(key) {
+				v[key], changed = RedactedValue, true
+
+				continue
+			}
+
+			if item, ok := r.value(item); ok {
+				v[key], changed = item, true
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if item, ok := r.value(item); ok {
+				v[i], changed = item, true
+			}
+		}
+	}
+
+	return value, changed
+}
+
+// With adds redacted fields to the wrapped core.
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactor.fields(fields)), redactor: c.redactor}
+}
+
+// Check adds core to the checked entry, when level is enabled by any of wrapped cores.
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write passes redacted fields to the wrapped core.
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return checkedWrite(c.Core, ent, c.redactor.fields(fields))
+}
+
+// checkedWrite writes entry through Check of the core, so only cores that accept entry
+// (e.g. teed cores with different levels) write it. It returns write errors of the cores.
+func checkedWrite(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	var out writeErrors
+
+	ce.ErrorOutput = &out
+	ce.Write(fields...)
+
+	return out.err
+}
+
+// Write keeps reported error, zap formats it as `<time> write error: <error>`.
+func (w *writeErrors) Write(data []byte) (int, error) {
+	msg := strings.TrimSpace(string(data))
+	if _, after, ok := strings.Cut(msg, " write error: "); ok {
+		msg = after
+	}
+
+	w.err = errors.Join(w.err, errors.New(msg))
+
+	return len(data), nil
+}
+
+// Sync does nothing.
+func (*writeErrors) Sync() error { return nil }
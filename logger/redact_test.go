@@ -0,0 +1,205 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type redactUser struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (u redactUser) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	enc.AddString("password", u.Password)
+
+	return nil
+}
+
+func TestParseRedact(t *testing.T) {
+	t.Run("should use default keys for empty list", func(t *testing.T) {
+		for _, value := range []string{"", " , "} {
+			out, err := parseRedact(value)
+			require.NoError(t, err)
+			require.NotNil(t, out)
+			require.True(t, out.match("password"))
+			require.True(t, out.match("access_token"))
+		}
+	})
+
+	t.Run("should return nil when redaction is disabled", func(t *testing.T) {
+		out, err := parseRedact(" - ")
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+
+	t.Run("should fail for invalid patterns", func(t *testing.T) {
+		_, err := parseRedact("/[a-/")
+		require.EqualError(t, err, "invalid redact pattern \"/[a-/\": error parsing regexp: missing closing ]: `[a-`")
+
+		_, err = parseRedact("[a-")
+		require.EqualError(t, err, "invalid redact pattern \"[a-\": syntax error in pattern")
+
+		cfg := Config{Level: "info", Trace: "fatal", Redact: "[a-"}
+		require.EqualError(t, cfg.Validate(context.Background()), "Redact: invalid redact pattern \"[a-\": syntax error in pattern.")
+	})
+
+	t.Run("should match keys", func(t *testing.T) {
+		out, err := parseRedact("Password, *_token, /^x-.+-key$/")
+		require.NoError(t, err)
+
+		cases := map[string]bool{
+			"password":      true,
+			"PASSWORD":      true,
+			"access_token":  true,
+			"Refresh_Token": true,
+			"X-Api-Key":     true,
+			"passwords":     false,
+			"token":         false,
+			"x-api-keys":    false,
+		}
+
+		for key, expect := range cases {
+			require.Equal(t, expect, out.match(key), key)
+		}
+	})
+}
+
+func TestRedactCore(t *testing.T) {
+	redact, err := parseRedact(defaultRedact)
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(redact.wrapCore(core)).Sugar()
+
+	decode := func(t *testing.T, v interface{}) interface{} {
+		t.Helper()
+
+		data, errMarshal := json.Marshal(v)
+		require.NoError(t, errMarshal)
+
+		var out interface{}
+		require.NoError(t, json.Unmarshal(data, &out))
+
+		return out
+	}
+
+	t.Run("should redact key/value pairs", func(t *testing.T) {
+		log.With("Authorization", "Bearer secret").Infow("message",
+			"user", "admin",
+			"password", "secret",
+			"access_token", "secret",
+			"count", 1)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, map[string]interface{}{
+			"Authorization": RedactedValue,
+			"user":          "admin",
+			"password":      RedactedValue,
+			"access_token":  RedactedValue,
+			"count":         int64(1),
+		}, entries[0].ContextMap())
+	})
+
+	t.Run("should redact nested objects", func(t *testing.T) {
+		user := redactUser{Name: "admin", Password: "secret"}
+
+		log.Infow("message",
+			"request", struct {
+				User    redactUser        `json:"user"`
+				Headers map[string]string `json:"headers"`
+			}{User: user, Headers: map[string]string{"Cookie": "secret", "Accept": "*/*"}},
+			"object", user,
+			"list", []map[string]interface{}{{"secret": "value", "id": 1}})
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+
+		fields := entries[0].ContextMap()
+		require.Equal(t, map[string]interface{}{
+			"user":    map[string]interface{}{"name": "admin", "password": RedactedValue},
+			"headers": map[string]interface{}{"Cookie": RedactedValue, "Accept": "*/*"},
+		}, decode(t, fields["request"]))
+		require.Equal(t, map[string]interface{}{"name": "admin", "password": RedactedValue}, decode(t, fields["object"]))
+		require.Equal(t, []interface{}{map[string]interface{}{"secret": RedactedValue, "id": float64(1)}}, decode(t, fields["list"]))
+	})
+
+	t.Run("should keep fields without sensitive keys", func(t *testing.T) {
+		user := struct{ Name string }{Name: "admin"}
+
+		log.Infow("message", "user", user)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, user, entries[0].ContextMap()["user"])
+	})
+
+	t.Run("should marshal only types that could contain sensitive keys", func(t *testing.T) {
+		type nested struct {
+			Token string `json:"-"`
+			Next  *nested
+			Items []struct {
+				ID int `json:"id"`
+			}
+		}
+
+		require.False(t, redact.reflected(reflect.TypeOf(nested{})))
+		require.False(t, redact.reflected(reflect.TypeOf(&struct{ Name string }{})))
+		require.True(t, redact.reflected(reflect.TypeOf(redactUser{})))
+		require.True(t, redact.reflected(reflect.TypeOf([]map[string]int{})))
+		require.True(t, redact.reflected(reflect.TypeOf(struct{ Raw json.RawMessage }{})))
+
+		_, ok := redact.types.Load(reflect.TypeOf(nested{}))
+		require.True(t, ok)
+	})
+
+	t.Run("should respect levels of teed cores", func(t *testing.T) {
+		errCore, errLogs := observer.New(zapcore.ErrorLevel)
+		teed := zap.New(redact.wrapCore(zapcore.NewTee(core, errCore))).Sugar()
+
+		teed.Infow("info", "password", "secret")
+		teed.Errorw("error", "password", "secret")
+
+		require.Len(t, logs.TakeAll(), 2)
+
+		entries := errLogs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, "error", entries[0].Message)
+		require.Equal(t, map[string]interface{}{"password": RedactedValue}, entries[0].ContextMap())
+	})
+}
+
+func TestNew_Redact(t *testing.T) {
+	cases := []struct {
+		name   string
+		redact string
+		expect string
+	}{
+		{name: "should redact default keys when value is empty", expect: `"password":"` + RedactedValue + `"`},
+		{name: "should not redact when redaction is disabled", redact: RedactDisabled, expect: `"password":"p"`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			log, err := New(Config{Level: "info", Trace: "fatal", Redact: tt.redact},
+				WithCustomOutput("redact-config-test", &fakeSink{Writer: out}))
+			require.NoError(t, err)
+
+			log.Infow("user signed in", "password", "p")
+			require.NoError(t, log.Sync())
+			require.Contains(t, out.String(), tt.expect)
+		})
+	}
+}
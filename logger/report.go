@@ -0,0 +1,490 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/im-kulikov/go-bones"
+)
+
+// ReportConfig provides configuration of error reporting to webhook or Sentry-compatible error tracker.
+type ReportConfig struct {
+	URL       string        `env:"URL" usage:"allows to report errors to webhook or sentry DSN" example:"https://key@sentry.io/42"`
+	Format    string        `env:"FORMAT" default:"webhook" enum:"webhook,sentry" usage:"allows to set report format"`
+	Level     string        `env:"LEVEL" default:"error" enum:"error,dpanic,panic,fatal" usage:"allows to set min reported level"`
+	BatchSize int           `env:"BATCH_SIZE" default:"10" usage:"allows to set count of errors sent at once"`
+	Interval  time.Duration `env:"INTERVAL" default:"5s" usage:"allows to set interval of sending errors"`
+	RateLimit int           `env:"RATE_LIMIT" default:"60" usage:"allows to set max count of errors per minute (0 disables limit)"`
+	Timeout   time.Duration `env:"TIMEOUT" default:"5s" usage:"allows to set timeout of report request"`
+}
+
+type (
+	// reportEvent describes reported error, it is sent to webhook as is.
+	reportEvent struct {
+		Time       time.Time              `json:"time"`
+		Level      string                 `json:"level"`
+		Logger     string                 `json:"logger,omitempty"`
+		Message    string                 `json:"message"`
+		Caller     string                 `json:"caller,omitempty"`
+		Error      string                 `json:"error,omitempty"`
+		ErrorType  string                 `json:"error_type,omitempty"`
+		Code       string                 `json:"code,omitempty"`
+		TraceID    string                 `json:"trace_id,omitempty"`
+		SpanID     string                 `json:"span_id,omitempty"`
+		App        string                 `json:"app,omitempty"`
+		Version    string                 `json:"version,omitempty"`
+		Stacktrace string                 `json:"stacktrace,omitempty"`
+		Fields     map[string]interface{} `json:"fields,omitempty"`
+
+		frames []runtime.Frame
+	}
+
+	// reportSender sends batch of events.
+	reportSender func(ctx context.Context, events []*reportEvent) error
+
+	// reportFlush requests to send queued events, events are sent until context is done.
+	reportFlush struct {
+		ctx  context.Context
+		done chan struct{}
+	}
+
+	// reporter collects events into batches and sends them in background goroutine,
+	// events over rate limit or queue capacity are dropped. When reporter was stopped,
+	// events are sent synchronously.
+	reporter struct {
+		mu     sync.Mutex
+		window time.Time
+		count  int
+
+		state   sync.RWMutex
+		stopped bool
+
+		cfg    ReportConfig
+		errs   zapcore.WriteSyncer // error output of the logger
+		send   reportSender
+		queue  chan *reportEvent
+		flush  chan reportFlush
+		done   chan struct{}
+		ctx    context.Context // canceled when reporter is stopped, so in-flight requests are aborted
+		cancel context.CancelFunc
+
+		app     string
+		version string
+	}
+
+	// reportCore reports entries with enabled level, it should be teed with core that writes entries.
+	reportCore struct {
+		zapcore.LevelEnabler
+
+		fields   []zapcore.Field
+		reporter *reporter
+	}
+)
+
+const (
+	// ReportFormatWebhook sends batch of errors as JSON array.
+	ReportFormatWebhook = "webhook"
+	// ReportFormatSentry sends errors as Sentry envelopes.
+	ReportFormatSentry = "sentry"
+
+	reportQueueFactor = 10
+	reportWindow      = time.Minute
+	reportSyncTimeout = time.Second * 5 // used when report timeout is not set
+)
+
+// nolint: gochecknoglobals
+var (
+	reportSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "log_reports_sent_total",
+		Help: "Count of errors sent to error tracker.",
+	})
+
+	reportDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "log_reports_dropped_total",
+		Help: "Count of errors dropped by rate limit, queue capacity or failed requests.",
+	})
+)
+
+var _ zapcore.Core = (*reportCore)(nil)
+
+// Validate checks report url (sentry format requires DSN) and that batch size is greater than zero,
+// when report url is passed.
+func (c ReportConfig) Validate() error {
+	enabled := c.URL != ""
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.URL, validation.By(func(interface{}) error {
+			_, err := newReportSender(c)
+
+			return err
+		})),
+		validation.Field(&c.BatchSize, validation.When(enabled, validation.Required, validation.Min(1))),
+		validation.Field(&c.Interval, validation.Min(time.Duration(0))),
+		validation.Field(&c.RateLimit, validation.Min(0)),
+		validation.Field(&c.Timeout, validation.Min(time.Duration(0))))
+}
+
+// newReportSender returns sender by report format, it returns nil when url is empty.
+func newReportSender(cfg ReportConfig) (reportSender, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+
+	client := &http.Client{Timeout: cfg.Timeout}
+	if cfg.Format == ReportFormatSentry {
+		return newSentrySender(client, cfg.URL)
+	}
+
+	if u, err := url.Parse(cfg.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid report url %q", cfg.URL)
+	}
+
+	return func(ctx context.Context, events []*reportEvent) error {
+		data, err := json.Marshal(events)
+		if err != nil {
+			return err
+		}
+
+		return reportPost(ctx, client, cfg.URL, data, http.Header{"Content-Type": {"application/json"}})
+	}, nil
+}
+
+// reportPost sends request and checks that response status is successful.
+func reportPost(ctx context.Context, client *http.Client, address string, data []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header = header
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected report response status %q", res.Status)
+	}
+
+	return nil
+}
+
+func newReporter(cfg ReportConfig, send reportSender, app, version string, errs zapcore.WriteSyncer) *reporter {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out := &reporter{
+		cfg:    cfg,
+		errs:   errs,
+		send:   send,
+		queue:  make(chan *reportEvent, cfg.BatchSize*reportQueueFactor),
+		flush:  make(chan reportFlush),
+		done:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+
+		app:     app,
+		version: version,
+	}
+
+	go out.run()
+
+	return out
+}
+
+// allow checks that rate limit is not exceeded in current window.
+func (r *reporter) allow(now time.Time) bool {
+	if r.cfg.RateLimit <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.window) >= reportWindow {
+		r.window, r.count = now, 0
+	}
+
+	r.count++
+
+	return r.count <= r.cfg.RateLimit
+}
+
+// report puts event into queue, it never blocks, when reporter is running.
+func (r *reporter) report(event *reportEvent) {
+	if !r.allow(time.Now()) {
+		reportDropped.Inc()
+
+		return
+	}
+
+	r.state.RLock()
+	defer r.state.RUnlock()
+
+	if r.stopped {
+		ctx, cancel := r.deadline(context.Background())
+		defer cancel()
+
+		r.sendBatch(ctx, []*reportEvent{event})
+
+		return
+	}
+
+	select {
+	case r.queue <- event:
+	default:
+		reportDropped.Inc()
+	}
+}
+
+// deadline returns context, that limits time of sending events by report timeout.
+func (r *reporter) deadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.cfg.Timeout > 0 {
+		return context.WithTimeout(ctx, r.cfg.Timeout)
+	}
+
+	return context.WithTimeout(ctx, reportSyncTimeout)
+}
+
+// sync waits until queued events are sent or context is done.
+func (r *reporter) sync(ctx context.Context) error {
+	req := reportFlush{ctx: ctx, done: make(chan struct{})}
+
+	select {
+	case r.flush <- req:
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("could not send reported errors: %w", ctx.Err())
+	}
+
+	select {
+	case <-req.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("could not send reported errors: %w", ctx.Err())
+	}
+}
+
+// stop sends queued events (until report timeout expires) and stops background goroutine,
+// next events are sent synchronously.
+func (r *reporter) stop() error {
+	r.state.Lock()
+	if r.stopped {
+		r.state.Unlock()
+
+		return nil
+	}
+
+	r.stopped = true
+	r.state.Unlock()
+
+	ctx, cancel := r.deadline(context.Background())
+	defer cancel()
+
+	err := r.sync(ctx)
+
+	r.cancel()
+	<-r.done
+
+	if count := len(r.queue); count > 0 {
+		reportDropped.Add(float64(count))
+	}
+
+	return err
+}
+
+func (r *reporter) run() {
+	defer close(r.done)
+
+	var tick <-chan time.Time
+	if r.cfg.Interval > 0 {
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	batch := make([]*reportEvent, 0, r.cfg.BatchSize)
+	for {
+		select {
+		case <-r.ctx.Done():
+			reportDropped.Add(float64(len(batch)))
+
+			return
+		case event := <-r.queue:
+			if batch = append(batch, event); len(batch) >= r.cfg.BatchSize || tick == nil {
+				batch = r.sendBatch(r.ctx, batch)
+			}
+		case <-tick:
+			batch = r.sendBatch(r.ctx, batch)
+		case req := <-r.flush:
+			for len(r.queue) > 0 {
+				if batch = append(batch, <-r.queue); len(batch) >= r.cfg.BatchSize {
+					batch = r.sendBatch(req.ctx, batch)
+				}
+			}
+
+			batch = r.sendBatch(req.ctx, batch)
+
+			close(req.done)
+		}
+	}
+}
+
+// sendBatch sends events and returns empty batch, request is limited by report timeout.
+func (r *reporter) sendBatch(ctx context.Context, batch []*reportEvent) []*reportEvent {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if r.cfg.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+	}
+
+	defer cancel()
+
+	if err := r.send(ctx, batch); err != nil {
+		reportDropped.Add(float64(len(batch)))
+
+		reportError(r.errs, "could not report %d errors: %s", len(batch), err)
+	} else {
+		reportSent.Add(float64(len(batch)))
+	}
+
+	return batch[:0]
+}
+
+// event converts entry and fields into reported event.
+func (r *reporter) event(ent zapcore.Entry, fields []zapcore.Field) *reportEvent {
+	out := &reportEvent{
+		Time:    ent.Time,
+		Level:   ent.Level.String(),
+		Logger:  ent.LoggerName,
+		Message: ent.Message,
+		App:     r.app,
+		Version: r.version,
+		frames:  reportFrames(),
+	}
+
+	if ent.Caller.Defined {
+		out.Caller = ent.Caller.TrimmedPath()
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(enc)
+
+		if err, ok := field.Interface.(error); ok && field.Type == zapcore.ErrorType && out.Error == "" {
+			out.Error, out.ErrorType, out.Code = err.Error(), fmt.Sprintf("%T", err), bones.ErrorCode(err)
+		}
+	}
+
+	out.TraceID, _ = enc.Fields[TraceIDKey].(string)
+	out.SpanID, _ = enc.Fields[SpanIDKey].(string)
+	out.Fields = enc.Fields
+
+	var stack strings.Builder
+	for _, frame := range out.frames {
+		_, _ = fmt.Fprintf(&stack, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+
+	out.Stacktrace = strings.TrimSuffix(stack.String(), "\n")
+
+	return out
+}
+
+// reportFrames returns stack frames of the caller, frames of zap and logger packages are skipped.
+func reportFrames() []runtime.Frame {
+	const maxDepth = 64
+
+	pcs := make([]uintptr, maxDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+
+	var (
+		out  []runtime.Frame
+		skip = true
+	)
+
+	for {
+		frame, more := frames.Next()
+
+		if skip = skip && internalFrame(frame); !skip {
+			out = append(out, frame)
+		}
+
+		if !more {
+			return out
+		}
+	}
+}
+
+// internalFrame checks that frame belongs to zap or logger package (except tests).
+func internalFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, "go.uber.org/zap") ||
+		(strings.HasPrefix(frame.Function, "github.com/im-kulikov/go-bones/logger.") && !strings.HasSuffix(frame.File, "_test.go"))
+}
+
+func newReportCore(level zapcore.Level, reporter *reporter) *reportCore {
+	return &reportCore{LevelEnabler: level, reporter: reporter}
+}
+
+// With adds structured context to the core.
+func (c *reportCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(append(clone.fields, c.fields...), fields...)
+
+	return &clone
+}
+
+// Check adds core to checked entry, when entry should be reported.
+func (c *reportCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write reports entry, reports are sent immediately, when program could crash (panic and fatal levels).
+func (c *reportCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	c.reporter.report(c.reporter.event(ent, append(append(all, c.fields...), fields...)))
+
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
+	return nil
+}
+
+// Sync waits until reported errors are sent, it waits no longer than report timeout.
+func (c *reportCore) Sync() error {
+	ctx, cancel := c.reporter.deadline(context.Background())
+	defer cancel()
+
+	return c.reporter.sync(ctx)
+}
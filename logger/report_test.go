@@ -0,0 +1,294 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/im-kulikov/go-bones"
+)
+
+type reportServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReportServer(t *testing.T, status int) *reportServer {
+	t.Helper()
+
+	srv := &reportServer{status: status}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		srv.mu.Lock()
+		srv.requests = append(srv.requests, r)
+		srv.bodies = append(srv.bodies, body)
+		srv.mu.Unlock()
+
+		w.WriteHeader(srv.status)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func (s *reportServer) received() ([]*http.Request, [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests, s.bodies
+}
+
+func TestReportConfig_Validate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config ReportConfig
+		error  string
+	}{
+		{name: "should be valid when url is empty", config: ReportConfig{}},
+		{name: "should be valid webhook", config: ReportConfig{URL: "http://localhost/errors", BatchSize: 1}},
+		{name: "should be valid sentry dsn", config: ReportConfig{URL: "https://key@sentry.io/42", Format: ReportFormatSentry, BatchSize: 1}},
+		{name: "should fail on invalid url", config: ReportConfig{URL: "localhost", BatchSize: 1}, error: `URL: invalid report url "localhost".`},
+		{
+			name:   "should fail on sentry dsn without key",
+			config: ReportConfig{URL: "https://sentry.io/42", Format: ReportFormatSentry, BatchSize: 1},
+			error:  `URL: invalid sentry dsn "https://sentry.io/42".`,
+		},
+		{
+			name:   "should fail on sentry dsn without project",
+			config: ReportConfig{URL: "https://key@sentry.io/", Format: ReportFormatSentry, BatchSize: 1},
+			error:  `URL: invalid sentry dsn "https://key@sentry.io/": project id is missing.`,
+		},
+		{name: "should fail on empty batch size", config: ReportConfig{URL: "http://localhost"}, error: "BatchSize: cannot be blank."},
+		{name: "should fail on negative rate limit", config: ReportConfig{RateLimit: -1}, error: "RateLimit: must be no less than 0."},
+	}
+
+	for i := range cases {
+		tt := cases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.error == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.EqualError(t, err, tt.error)
+		})
+	}
+}
+
+func TestReportCore(t *testing.T) {
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctx := trace.ContextWithSpanContext(context.Background(), span)
+	errNotFound := bones.Error{Code: "not_found", Message: "user not found"}
+
+	var outputs int
+
+	newLogger := func(t *testing.T, cfg ReportConfig) Logger {
+		t.Helper()
+
+		outputs++
+
+		log, err := New(Config{Level: "info", Trace: "fatal", Redact: "password", Report: cfg},
+			WithAppName("report-test"), WithAppVersion("1.2.3"),
+			WithCustomOutput(fmt.Sprintf("report-test-%d", outputs), &fakeSink{Writer: io.Discard}))
+		require.NoError(t, err)
+
+		return log
+	}
+
+	t.Run("should send batches to webhook", func(t *testing.T) {
+		srv := newReportServer(t, http.StatusOK)
+		log := newLogger(t, ReportConfig{URL: srv.URL, BatchSize: 2, Interval: time.Hour, Timeout: time.Second})
+		before := testutil.ToFloat64(reportSent)
+
+		log.Info("should not be reported")
+		log.Warn("should not be reported")
+		log.ErrorwCtx(ctx, "could not find user", "error", errNotFound, "user", 42)
+		log.Named("worker").Error("second error")
+		log.Errorw("third error", "error", errors.New("plain"), "password", "secret")
+
+		require.NoError(t, log.Sync())
+
+		requests, bodies := srv.received()
+		require.Len(t, requests, 2)
+		require.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+		require.Equal(t, float64(3), testutil.ToFloat64(reportSent)-before)
+
+		var first, second []map[string]interface{}
+		require.NoError(t, json.Unmarshal(bodies[0], &first))
+		require.NoError(t, json.Unmarshal(bodies[1], &second))
+		require.Len(t, first, 2)
+		require.Len(t, second, 1)
+
+		event := first[0]
+		require.Equal(t, "error", event["level"])
+		require.Equal(t, "could not find user", event["message"])
+		require.Equal(t, errNotFound.Error(), event["error"])
+		require.Equal(t, "bones.Error", event["error_type"])
+		require.Equal(t, "not_found", event["code"])
+		require.Equal(t, span.TraceID().String(), event["trace_id"])
+		require.Equal(t, span.SpanID().String(), event["span_id"])
+		require.Equal(t, "report-test", event["app"])
+		require.Equal(t, "1.2.3", event["version"])
+		require.Contains(t, event["caller"], "logger/report_test.go")
+		require.True(t, strings.HasPrefix(event["stacktrace"].(string), "github.com/im-kulikov/go-bones/logger.TestReportCore"))
+		require.Equal(t, float64(42), event["fields"].(map[string]interface{})["user"])
+
+		require.Equal(t, "worker", first[1]["logger"])
+		require.Equal(t, "plain", second[0]["error"])
+		require.Empty(t, second[0]["code"])
+		require.Equal(t, RedactedValue, second[0]["fields"].(map[string]interface{})["password"])
+	})
+
+	t.Run("should send events to sentry", func(t *testing.T) {
+		srv := newReportServer(t, http.StatusOK)
+		dsn := strings.Replace(srv.URL, "://", "://public@", 1) + "/42"
+		log := newLogger(t, ReportConfig{URL: dsn, Format: ReportFormatSentry, BatchSize: 10, Interval: time.Hour})
+
+		log.ErrorwCtx(ctx, "could not find user", "error", errNotFound)
+		log.Error("second error")
+
+		require.NoError(t, log.Sync())
+
+		requests, bodies := srv.received()
+		require.Len(t, requests, 2)
+		require.Equal(t, "/api/42/envelope/", requests[0].URL.Path)
+		require.Contains(t, requests[0].Header.Get("X-Sentry-Auth"), "sentry_key=public")
+
+		scanner := bufio.NewScanner(strings.NewReader(string(bodies[0])))
+
+		var header, item, event map[string]interface{}
+		for _, out := range []*map[string]interface{}{&header, &item, &event} {
+			require.True(t, scanner.Scan())
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), out))
+		}
+
+		require.Equal(t, dsn, header["dsn"])
+		require.Equal(t, header["event_id"], event["event_id"])
+		require.Equal(t, "event", item["type"])
+		require.Equal(t, "error", event["level"])
+		require.Equal(t, "1.2.3", event["release"])
+		require.Equal(t, map[string]interface{}{"formatted": "could not find user"}, event["message"])
+		require.Equal(t, "not_found", event["tags"].(map[string]interface{})["code"])
+		require.Equal(t, "report-test", event["tags"].(map[string]interface{})["app"])
+		require.Equal(t, span.TraceID().String(),
+			event["contexts"].(map[string]interface{})["trace"].(map[string]interface{})["trace_id"])
+
+		exception := event["exception"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "bones.Error", exception["type"])
+		require.Equal(t, errNotFound.Error(), exception["value"])
+
+		frames := exception["stacktrace"].(map[string]interface{})["frames"].([]interface{})
+		require.Contains(t, frames[len(frames)-1].(map[string]interface{})["function"], "TestReportCore")
+	})
+
+	t.Run("should drop events over rate limit or when request failed", func(t *testing.T) {
+		srv := newReportServer(t, http.StatusInternalServerError)
+		log := newLogger(t, ReportConfig{URL: srv.URL, BatchSize: 10, Interval: time.Hour, RateLimit: 2})
+		before := testutil.ToFloat64(reportDropped)
+
+		for i := 0; i < 5; i++ {
+			log.Error("repeated error")
+		}
+
+		require.NoError(t, log.Sync())
+
+		requests, bodies := srv.received()
+		require.Len(t, requests, 1)
+
+		var events []map[string]interface{}
+		require.NoError(t, json.Unmarshal(bodies[0], &events))
+		require.Len(t, events, 2)
+
+		// 3 events are dropped by rate limit and 2 events are dropped by failed request
+		require.Equal(t, float64(5), testutil.ToFloat64(reportDropped)-before)
+	})
+	t.Run("should stop reporter on close and report synchronously after it", func(t *testing.T) {
+		srv := newReportServer(t, http.StatusOK)
+		log := newLogger(t, ReportConfig{URL: srv.URL, BatchSize: 10, Interval: time.Hour, Timeout: time.Second})
+
+		log.Error("queued error")
+		require.NoError(t, log.Close())
+
+		requests, _ := srv.received()
+		require.Len(t, requests, 1)
+
+		select {
+		case <-log.(*logger).reporter.done:
+		default:
+			require.Fail(t, "reporter should be stopped")
+		}
+
+		log.Error("error after close")
+
+		requests, _ = srv.received()
+		require.Len(t, requests, 2)
+		require.NoError(t, log.Close())
+	})
+}
+
+func TestReporter_sync(t *testing.T) {
+	var (
+		sent    = make(chan int, 2)
+		started = make(chan struct{}, 1)
+		release = make(chan struct{})
+	)
+
+	rep := newReporter(ReportConfig{BatchSize: 1, Timeout: time.Minute}, func(ctx context.Context, events []*reportEvent) error {
+		started <- struct{}{}
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		sent <- len(events)
+
+		return nil
+	}, "", "", nil)
+
+	rep.report(&reportEvent{Message: "blocked"})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	// background goroutine is blocked by request, sync should not wait for it
+	require.ErrorIs(t, rep.sync(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, rep.stop())
+	require.Equal(t, 1, <-sent)
+
+	rep.report(&reportEvent{Message: "after stop"})
+	<-started
+	require.Equal(t, 1, <-sent)
+	require.NoError(t, rep.sync(context.Background()))
+}
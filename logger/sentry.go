@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// sentryEvent is a subset of Sentry event payload.
+	sentryEvent struct {
+		EventID   string                 `json:"event_id"`
+		Timestamp string                 `json:"timestamp"`
+		Level     string                 `json:"level"`
+		Logger    string                 `json:"logger,omitempty"`
+		Platform  string                 `json:"platform"`
+		Release   string                 `json:"release,omitempty"`
+		Message   sentryMessage          `json:"message"`
+		Exception []sentryException      `json:"exception,omitempty"`
+		Tags      map[string]string      `json:"tags,omitempty"`
+		Contexts  map[string]interface{} `json:"contexts,omitempty"`
+		Extra     map[string]interface{} `json:"extra,omitempty"`
+	}
+
+	sentryMessage struct {
+		Formatted string `json:"formatted"`
+	}
+
+	sentryException struct {
+		Type       string           `json:"type"`
+		Value      string           `json:"value"`
+		Stacktrace sentryStacktrace `json:"stacktrace"`
+	}
+
+	sentryStacktrace struct {
+		Frames []sentryFrame `json:"frames"`
+	}
+
+	sentryFrame struct {
+		Function string `json:"function"`
+		AbsPath  string `json:"abs_path"`
+		Line     int    `json:"lineno"`
+	}
+)
+
+const (
+	sentryVersion  = 7
+	sentryClient   = "go-bones/1.0"
+	sentryPlatform = "go"
+)
+
+// newSentrySender parses DSN (`https://public_key@host/project_id`) and returns sender,
+// that sends each event as Sentry envelope.
+func newSentrySender(client *http.Client, dsn string) (reportSender, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid sentry dsn %q", dsn)
+	}
+
+	idx := strings.LastIndex(u.Path, "/")
+	if idx < 0 || u.Path[idx+1:] == "" {
+		return nil, fmt.Errorf("invalid sentry dsn %q: project id is missing", dsn)
+	}
+
+	address := fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, u.Path[:idx], u.Path[idx+1:])
+	auth := fmt.Sprintf("Sentry sentry_version=%d, sentry_client=%s, sentry_key=%s",
+		sentryVersion, sentryClient, u.User.Username())
+	header := http.Header{"Content-Type": {"application/x-sentry-envelope"}, "X-Sentry-Auth": {auth}}
+
+	return func(ctx context.Context, events []*reportEvent) error {
+		var err error
+		for _, event := range events {
+			data, errEnvelope := sentryEnvelope(dsn, event)
+			if errEnvelope == nil {
+				errEnvelope = reportPost(ctx, client, address, data, header.Clone())
+			}
+
+			err = errors.Join(err, errEnvelope)
+		}
+
+		return err
+	}, nil
+}
+
+// sentryEnvelope encodes event into envelope, that contains only one event item.
+func sentryEnvelope(dsn string, event *reportEvent) ([]byte, error) {
+	payload := newSentryEvent(event)
+
+	item, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	out := new(bytes.Buffer)
+	enc := json.NewEncoder(out)
+
+	sentAt := time.Now().UTC().Format(time.RFC3339)
+	if err = enc.Encode(map[string]string{"event_id": payload.EventID, "dsn": dsn, "sent_at": sentAt}); err != nil {
+		return nil, err
+	}
+
+	if err = enc.Encode(map[string]interface{}{"type": "event", "length": len(item)}); err != nil {
+		return nil, err
+	}
+
+	out.Write(item)
+	out.WriteByte('\n')
+
+	return out.Bytes(), nil
+}
+
+// newSentryEvent converts reported event into Sentry event, Sentry expects frames from the oldest to the newest.
+func newSentryEvent(event *reportEvent) *sentryEvent {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	out := &sentryEvent{
+		EventID:   hex.EncodeToString(id),
+		Timestamp: event.Time.UTC().Format(time.RFC3339Nano),
+		Level:     sentryLevel(event.Level),
+		Logger:    event.Logger,
+		Platform:  sentryPlatform,
+		Release:   event.Version,
+		Message:   sentryMessage{Formatted: event.Message},
+		Tags:      make(map[string]string),
+		Extra:     event.Fields,
+	}
+
+	for key, value := range map[string]string{"app": event.App, "code": event.Code, "caller": event.Caller} {
+		if value != "" {
+			out.Tags[key] = value
+		}
+	}
+
+	if event.TraceID != "" {
+		out.Contexts = map[string]interface{}{"trace": map[string]string{"trace_id": event.TraceID, "span_id": event.SpanID}}
+	}
+
+	exception := sentryException{Type: event.ErrorType, Value: event.Error}
+	if exception.Type == "" {
+		exception.Type, exception.Value = event.Level, event.Message
+	}
+
+	for i := len(event.frames) - 1; i >= 0; i-- {
+		frame := event.frames[i]
+		exception.Stacktrace.Frames = append(exception.Stacktrace.Frames,
+			sentryFrame{Function: frame.Function, AbsPath: frame.File, Line: frame.Line})
+	}
+
+	out.Exception = []sentryException{exception}
+
+	return out
+}
+
+// sentryLevel maps zap level to Sentry level.
+func sentryLevel(level string) string {
+	switch level {
+	case "dpanic", "panic", "fatal":
+		return "fatal"
+	default:
+		return level
+	}
+}
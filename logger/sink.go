@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sinkScheme is a single zap sink scheme, that used to pass internal and registered sinks into zap.Config.
+const sinkScheme = "go-bones"
+
+// defaultOutputs should be in sync with Config.Outputs default value,
+// default outputs are replaced by file, syslog and journald outputs, explicitly configured outputs are kept.
+const defaultOutputs = "stderr"
+
+// nolint: gochecknoglobals
+var (
+	sinks       sync.Map // internal sinks, that are opened once
+	namedSinks  sync.Map // sinks registered by RegisterSink
+	sinkCounter atomic.Uint64
+	sinkOnce    sync.Once
+	sinkErr     error
+)
+
+// RegisterSink allows to use sink as output by name (e.g. `LOGGER_OUTPUT_PATHS=stderr,kafka`),
+// sink with the same name is replaced, so it could be registered by each logger.New call (e.g. in tests).
+// Name should not be empty or a number, it returns path, that could be used in zap.Config output paths.
+func RegisterSink(name string, sink Sink) (string, error) {
+	if _, err := strconv.ParseUint(name, 10, 64); name == "" || err == nil {
+		return "", fmt.Errorf("invalid sink name %q", name)
+	}
+
+	if sink == nil {
+		return "", errors.New("sink should not be nil")
+	}
+
+	if err := registerScheme(); err != nil {
+		return "", err
+	}
+
+	namedSinks.Store(name, sink)
+
+	return sinkScheme + ":" + name, nil
+}
+
+// registerSink stores sink in internal registry and returns URL that could be used in zap.Config output paths.
+func registerSink(sink Sink) (string, error) {
+	if err := registerScheme(); err != nil {
+		return "", err
+	}
+
+	id := strconv.FormatUint(sinkCounter.Add(1), 10)
+	sinks.Store(id, sink)
+
+	return sinkScheme + ":" + id, nil
+}
+
+// registerScheme registers zap sink scheme once, internal sinks are removed from registry when they are opened.
+func registerScheme() error {
+	sinkOnce.Do(func() {
+		sinkErr = zap.RegisterSink(sinkScheme, func(u *url.URL) (Sink, error) {
+			if sink, ok := sinks.LoadAndDelete(u.Opaque); ok {
+				return sink.(Sink), nil
+			}
+
+			if sink, ok := namedSinks.Load(u.Opaque); ok {
+				return sink.(Sink), nil
+			}
+
+			return nil, fmt.Errorf("unknown sink %q", u.String())
+		})
+	})
+
+	return sinkErr
+}
+
+// reportError writes internal error of background goroutines (e.g. async output, file cleanup) into error output
+// of the logger (LOGGER_ERROR_OUTPUT_PATHS) in the same format as zap does, stderr is used when output is not passed.
+func reportError(out zapcore.WriteSyncer, format string, args ...interface{}) {
+	if out == nil {
+		out = zapcore.Lock(os.Stderr)
+	}
+
+	_, _ = fmt.Fprintf(out, "%v "+format+"\n", append([]interface{}{time.Now().UTC()}, args...)...)
+	_ = out.Sync()
+}
+
+// outputPaths parses comma separated output paths, names of registered sinks are replaced with their paths.
+func outputPaths(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		if _, ok := namedSinks.Load(item); ok {
+			item = sinkScheme + ":" + item
+		}
+
+		out = append(out, item)
+	}
+
+	return out
+}
@@ -0,0 +1,279 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// slogHandler implements slog.Handler over zapcore.Core.
+	slogHandler struct {
+		core zapcore.Core
+		name string
+	}
+
+	// slogCore implements zapcore.Core over slog.Handler.
+	slogCore struct {
+		handler slog.Handler
+	}
+)
+
+const slogLoggerKey = "logger"
+
+var (
+	_ slog.Handler = (*slogHandler)(nil)
+	_ zapcore.Core = (*slogCore)(nil)
+)
+
+// Slog returns slog.Logger that writes into the same zap core (levels, sampling and fields are preserved).
+func (l *logger) Slog() *slog.Logger {
+	base := l.Desugar()
+
+	return slog.New(&slogHandler{core: base.Core(), name: base.Name()})
+}
+
+// NewFromSlog creates Logger that writes into passed slog.Handler,
+// logger level could be changed at runtime by Levels, but handler could filter entries too.
+func NewFromSlog(handler slog.Handler) Logger {
+	levels := newLevels(zap.NewAtomicLevelAt(zapcore.DebugLevel))
+
+	return &logger{
+		levels:        levels,
+		every:         new(sync.Map),
+		SugaredLogger: zap.New(levels.wrapCore(&slogCore{handler: handler}), zap.AddCaller()).Sugar(),
+	}
+}
+
+// zapLevel converts slog.Level into zapcore.Level.
+func zapLevel(lvl slog.Level) zapcore.Level {
+	switch {
+	case lvl < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case lvl < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case lvl < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// slogLevel converts zapcore.Level into slog.Level, levels above error are mapped to error+N.
+func slogLevel(lvl zapcore.Level) slog.Level {
+	switch {
+	case lvl <= zapcore.DebugLevel:
+		return slog.LevelDebug
+	case lvl == zapcore.InfoLevel:
+		return slog.LevelInfo
+	case lvl == zapcore.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError + slog.Level(lvl-zapcore.ErrorLevel)
+	}
+}
+
+// Enabled reports whether core enables passed level.
+func (h *slogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return h.core.Enabled(zapLevel(lvl))
+}
+
+// Handle writes slog.Record into zap core.
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	ent := zapcore.Entry{
+		Level:      zapLevel(record.Level),
+		Time:       record.Time,
+		Message:    record.Message,
+		LoggerName: h.name,
+	}
+
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ent.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+
+	ce := h.core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	fields := make([]zapcore.Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, attr)
+
+		return true
+	})
+
+	ce.Write(fields...)
+
+	return nil
+}
+
+// WithAttrs returns handler with passed attributes.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zapcore.Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendAttr(fields, attr)
+	}
+
+	return &slogHandler{core: h.core.With(fields), name: h.name}
+}
+
+// WithGroup returns handler that puts following attributes into group.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{core: h.core.With([]zapcore.Field{zap.Namespace(name)}), name: h.name}
+}
+
+// appendAttr converts slog.Attr into zap fields, empty attributes are skipped and groups without key are inlined.
+func appendAttr(fields []zapcore.Field, attr slog.Attr) []zapcore.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	value := attr.Value
+	switch value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, value.Time()))
+	case slog.KindGroup:
+		group := value.Group()
+		if len(group) == 0 {
+			return fields
+		}
+
+		if attr.Key == "" {
+			for _, item := range group {
+				fields = appendAttr(fields, item)
+			}
+
+			return fields
+		}
+
+		return append(fields, zap.Object(attr.Key, slogGroup(group)))
+	default:
+		return append(fields, zap.Any(attr.Key, value.Any()))
+	}
+}
+
+// slogGroup marshals group attributes as nested object.
+type slogGroup []slog.Attr
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range g {
+		for _, field := range appendAttr(nil, attr) {
+			field.AddTo(enc)
+		}
+	}
+
+	return nil
+}
+
+// Enabled reports whether handler enables passed level.
+func (c *slogCore) Enabled(lvl zapcore.Level) bool {
+	return c.handler.Enabled(context.Background(), slogLevel(lvl))
+}
+
+// With returns core with handler that contains passed fields,
+// namespaces are converted into groups, that contain all following fields.
+func (c *slogCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+
+	handler, last := c.handler, 0
+	for i, field := range fields {
+		if field.Type != zapcore.NamespaceType {
+			continue
+		}
+
+		if attrs := fieldsToAttrs(fields[last:i]); len(attrs) > 0 {
+			handler = handler.WithAttrs(attrs)
+		}
+
+		handler, last = handler.WithGroup(field.Key), i+1
+	}
+
+	if attrs := fieldsToAttrs(fields[last:]); len(attrs) > 0 {
+		handler = handler.WithAttrs(attrs)
+	}
+
+	return &slogCore{handler: handler}
+}
+
+// Check adds core to the checked entry, when level is enabled.
+func (c *slogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+// Write passes entry to the slog.Handler.
+func (c *slogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	record := slog.NewRecord(ent.Time, slogLevel(ent.Level), ent.Message, ent.Caller.PC)
+	if ent.LoggerName != "" {
+		record.AddAttrs(slog.String(slogLoggerKey, ent.LoggerName))
+	}
+
+	record.AddAttrs(fieldsToAttrs(fields)...)
+
+	return c.handler.Handle(context.Background(), record)
+}
+
+// Sync does nothing, slog.Handler has no flush method.
+func (c *slogCore) Sync() error { return nil }
+
+// fieldsToAttrs converts zap fields into slog attributes, namespaces are converted into groups.
+func fieldsToAttrs(fields []zapcore.Field) []slog.Attr {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	return mapToAttrs(enc.Fields)
+}
+
+func mapToAttrs(values map[string]interface{}) []slog.Attr {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	out := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		if nested, ok := values[key].(map[string]interface{}); ok {
+			out = append(out, slog.Attr{Key: key, Value: slog.GroupValue(mapToAttrs(nested)...)})
+
+			continue
+		}
+
+		out = append(out, slog.Any(key, values[key]))
+	}
+
+	return out
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSlog(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	levels := newLevels(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	log := &logger{
+		levels:        levels,
+		SugaredLogger: zap.New(levels.wrapCore(core)).Sugar().With("app", "test"),
+	}
+
+	t.Run("should write into zap core", func(t *testing.T) {
+		log.Slog().Info("message", "key", "val", slog.Int("num", 1))
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, zapcore.InfoLevel, entries[0].Level)
+		require.Equal(t, "message", entries[0].Message)
+		require.Equal(t, "slog_test.go", filepath.Base(entries[0].Caller.File))
+		require.Equal(t, map[string]interface{}{"app": "test", "key": "val", "num": int64(1)}, entries[0].ContextMap())
+	})
+
+	t.Run("should respect logger levels", func(t *testing.T) {
+		log.Slog().Debug("skipped")
+		require.Empty(t, logs.TakeAll())
+
+		levels.SetNamedLevel("worker", zapcore.DebugLevel, 0)
+		defer levels.Restore()
+
+		log.Named("worker").Slog().Debug("debug")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, "worker", entries[0].LoggerName)
+		require.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	})
+
+	t.Run("should keep attributes and groups", func(t *testing.T) {
+		log.Slog().
+			With("user", "admin").
+			WithGroup("req").
+			Warn("grouped", "id", 1, slog.Group("http", "code", 200), slog.Group("", "inline", true))
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		require.Equal(t, zapcore.WarnLevel, entries[0].Level)
+		require.Equal(t, map[string]interface{}{
+			"app":  "test",
+			"user": "admin",
+			"req": map[string]interface{}{
+				"id":     int64(1),
+				"inline": true,
+				"http":   map[string]interface{}{"code": int64(200)},
+			},
+		}, entries[0].ContextMap())
+	})
+}
+
+func TestNewFromSlog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	log := NewFromSlog(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true}))
+
+	decode := func(t *testing.T) map[string]interface{} {
+		t.Helper()
+
+		defer buf.Reset()
+
+		out := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+		return out
+	}
+
+	t.Run("should filter entries by handler level", func(t *testing.T) {
+		log.Debugw("skipped")
+		require.Empty(t, buf.String())
+	})
+
+	t.Run("should write entries into handler", func(t *testing.T) {
+		log.Named("worker").With("app", "test").Errorw("message", "key", "val", "took", time.Second)
+
+		out := decode(t)
+		require.Equal(t, "ERROR", out["level"])
+		require.Equal(t, "message", out["msg"])
+		require.Equal(t, "worker", out[slogLoggerKey])
+		require.Equal(t, "test", out["app"])
+		require.Equal(t, "val", out["key"])
+		require.Equal(t, float64(time.Second), out["took"])
+		require.Contains(t, out["source"], "function")
+	})
+
+	t.Run("should convert namespaces into groups", func(t *testing.T) {
+		log.With(zap.Namespace("req")).Warnw("grouped", "id", 1)
+
+		out := decode(t)
+		require.Equal(t, "WARN", out["level"])
+		require.Equal(t, map[string]interface{}{"id": float64(1)}, out["req"])
+	})
+
+	t.Run("should change level at runtime", func(t *testing.T) {
+		log.Levels().SetLevel(zapcore.WarnLevel, 0)
+		defer log.Levels().SetLevel(zapcore.DebugLevel, 0)
+
+		log.Infow("skipped")
+		require.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.uber.org/zap/zapcore"
+)
+
+// SyslogConfig provides configuration of RFC 5424 syslog output.
+type SyslogConfig struct {
+	Address  string `env:"ADDRESS" usage:"allows to write logs to syslog server" example:"localhost:514"`
+	Network  string `env:"NETWORK" default:"udp" enum:"udp,tcp,unix,unixgram" usage:"allows to set syslog network"`
+	Facility string `env:"FACILITY" default:"user" usage:"allows to set syslog facility (e.g. user, daemon, local0)"`
+	Tag      string `env:"TAG" usage:"allows to set syslog app name (app name by default)"`
+}
+
+type (
+	// socketWriter writes messages into socket, it dials lazily and redials when write fails,
+	// so logger could be created when syslog server or journald is not available yet.
+	// Dial and write are bounded by timeout, failed dials are retried with exponential backoff,
+	// so dead collector does not block logging.
+	socketWriter struct {
+		mu   sync.Mutex
+		conn net.Conn
+
+		network string
+		address string
+		timeout time.Duration
+
+		backoff time.Duration // current backoff, zero when connection is healthy
+		retry   time.Time     // dials are not allowed until retry time
+		err     error         // the last dial or write error
+		now     func() time.Time
+	}
+
+	// syslogCore writes entries encoded by logger encoder as RFC 5424 messages,
+	// zap levels are mapped to syslog severities.
+	syslogCore struct {
+		zapcore.LevelEnabler
+
+		enc    zapcore.Encoder
+		out    *socketWriter
+		stream bool
+
+		facility int
+		hostname string
+		appName  string
+		procID   string
+	}
+)
+
+const (
+	socketTimeout    = time.Second
+	socketMinBackoff = time.Millisecond * 100
+	socketMaxBackoff = time.Second * 30
+
+	syslogVersion   = 1
+	syslogNilValue  = "-"
+	syslogMaxApp    = 48
+	syslogMaxHost   = 255
+	syslogTimestamp = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// nolint: gochecknoglobals
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var _ zapcore.Core = (*syslogCore)(nil)
+
+var errSocketBackoff = errors.New("connection is not established, waiting before the next attempt")
+
+// Validate checks that syslog facility is known.
+func (c SyslogConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Facility, validation.By(func(interface{}) error {
+			_, err := syslogFacility(c.Facility)
+
+			return err
+		})))
+}
+
+func syslogFacility(name string) (int, error) {
+	if name == "" {
+		return syslogFacilities["user"], nil
+	}
+
+	facility, ok := syslogFacilities[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+
+	return facility, nil
+}
+
+// syslogSeverity maps zap level to syslog severity.
+func syslogSeverity(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 7 // debug
+	case zapcore.InfoLevel:
+		return 6 // informational
+	case zapcore.WarnLevel:
+		return 4 // warning
+	case zapcore.ErrorLevel:
+		return 3 // error
+	case zapcore.DPanicLevel:
+		return 2 // critical
+	case zapcore.PanicLevel:
+		return 1 // alert
+	default:
+		return 0 // emergency
+	}
+}
+
+func newSyslogCore(cfg SyslogConfig, enc zapcore.Encoder, appName string) (*syslogCore, error) {
+	facility, err := syslogFacility(cfg.Facility)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Tag != "" {
+		appName = cfg.Tag
+	}
+
+	hostname, _ := os.Hostname()
+
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+
+	return &syslogCore{
+		LevelEnabler: zapcore.DebugLevel,
+
+		enc:    enc,
+		out:    newSocketWriter(network, cfg.Address),
+		stream: network == "tcp" || network == "unix",
+
+		facility: facility,
+		hostname: syslogHeader(hostname, syslogMaxHost),
+		appName:  syslogHeader(appName, syslogMaxApp),
+		procID:   strconv.Itoa(os.Getpid()),
+	}, nil
+}
+
+// syslogHeader replaces empty or non-printable header values with nil value and truncates them.
+func syslogHeader(value string, limit int) string {
+	if value == "" {
+		return syslogNilValue
+	}
+
+	out := []byte(value)
+	for i, c := range out {
+		if c < '!' || c > '~' {
+			out[i] = '_'
+		}
+	}
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return string(out)
+}
+
+// With adds structured context to the core.
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.enc = c.enc.Clone()
+
+	for i := range fields {
+		fields[i].AddTo(clone.enc)
+	}
+
+	return &clone
+}
+
+// Check adds core to checked entry, levels are checked by wrapped core.
+func (c *syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write encodes entry and sends it as RFC 5424 message:
+// `<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG`.
+func (c *syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	msg, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+
+	defer msg.Free()
+
+	out := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(out, "<%d>%d %s %s %s %s %s %s ",
+		c.facility*8+syslogSeverity(ent.Level), syslogVersion, ent.Time.Format(syslogTimestamp),
+		c.hostname, c.appName, c.procID, syslogNilValue, syslogNilValue)
+	out.Write(bytes.TrimRight(msg.Bytes(), "\n"))
+
+	return c.out.write(c.frame(out.Bytes()))
+}
+
+// frame uses octet counting (RFC 6587) for stream connections.
+func (c *syslogCore) frame(msg []byte) []byte {
+	if !c.stream {
+		return msg
+	}
+
+	out := strconv.AppendInt(make([]byte, 0, len(msg)+8), int64(len(msg)), 10)
+	out = append(out, ' ')
+
+	return append(out, msg...)
+}
+
+// Sync does nothing, because messages are not buffered.
+func (c *syslogCore) Sync() error { return nil }
+
+func newSocketWriter(network, address string) *socketWriter {
+	return &socketWriter{network: network, address: address, timeout: socketTimeout, now: time.Now}
+}
+
+// write sends message, it redials once when write fails, and fails fast until backoff expires,
+// when connection could not be established.
+func (w *socketWriter) write(msg []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			if now.Before(w.retry) {
+				return fmt.Errorf("%w: %w", errSocketBackoff, w.err)
+			}
+
+			if w.conn, err = net.DialTimeout(w.network, w.address, w.timeout); err != nil {
+				w.fail(now, err)
+
+				return err
+			}
+		}
+
+		_ = w.conn.SetWriteDeadline(now.Add(w.timeout))
+		if _, err = w.conn.Write(msg); err == nil {
+			w.backoff = 0
+
+			return nil
+		}
+
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	w.fail(now, err)
+
+	return err
+}
+
+// fail doubles backoff (up to max backoff) and forbids dials until it expires.
+func (w *socketWriter) fail(now time.Time, err error) {
+	switch {
+	case w.backoff <= 0:
+		w.backoff = socketMinBackoff
+	case w.backoff < socketMaxBackoff:
+		w.backoff = min(w.backoff*2, socketMaxBackoff)
+	}
+
+	w.retry, w.err = now.Add(w.backoff), err
+}
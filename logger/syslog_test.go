@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSyslogConfig_Validate(t *testing.T) {
+	require.NoError(t, SyslogConfig{}.Validate())
+	require.NoError(t, SyslogConfig{Facility: "local7"}.Validate())
+	require.EqualError(t, SyslogConfig{Facility: "unknown"}.Validate(), `Facility: unknown syslog facility "unknown".`)
+}
+
+func TestSyslogCore(t *testing.T) {
+	header := regexp.MustCompile(`^<(\d+)>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}\S+ \S+ (\S+) (\d+) - - (.+)$`)
+
+	t.Run("should write entries over udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		log, err := New(Config{
+			Level:  "debug",
+			Trace:  "fatal",
+			Redact: "password",
+			Syslog: SyslogConfig{Address: conn.LocalAddr().String(), Network: "udp", Facility: "local0"},
+		}, WithAppName("syslog-test"))
+		require.NoError(t, err)
+
+		cases := []struct {
+			write    func(msg string, args ...interface{})
+			severity int
+		}{
+			{write: log.Debugw, severity: 7},
+			{write: log.Infow, severity: 6},
+			{write: log.Warnw, severity: 4},
+			{write: log.Errorw, severity: 3},
+		}
+
+		buf := make([]byte, 64*1024)
+		for _, tt := range cases {
+			tt.write("syslog message", "user", "admin", "password", "secret")
+
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+			n, _, errRead := conn.ReadFrom(buf)
+			require.NoError(t, errRead)
+
+			matches := header.FindStringSubmatch(string(buf[:n]))
+			require.Len(t, matches, 5, string(buf[:n]))
+			require.Equal(t, strconv.Itoa(16*8+tt.severity), matches[1])
+			require.Equal(t, "syslog-test", matches[2])
+			require.Equal(t, strconv.Itoa(os.Getpid()), matches[3])
+			require.Contains(t, matches[4], `"msg":"syslog message"`)
+			require.Contains(t, matches[4], `"user":"admin"`)
+			require.Contains(t, matches[4], `"password":"[REDACTED]"`)
+		}
+	})
+
+	t.Run("should write entries over tcp with octet counting", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, lis.Close()) }()
+
+		core, err := newSyslogCore(SyslogConfig{Address: lis.Addr().String(), Network: "tcp", Tag: "tcp test"},
+			NewLogfmtEncoder(zap.NewProductionEncoderConfig()), "ignored")
+		require.NoError(t, err)
+
+		log := zap.New(core).With(zap.String("service", "api"))
+		log.Warn("first")
+		log.Error("second", zap.Int("attempt", 2))
+
+		conn, err := lis.Accept()
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, conn.Close()) }()
+
+		reader := bufio.NewReader(conn)
+		for _, expect := range []string{`msg=first service=api`, `msg=second service=api attempt=2`} {
+			size, errRead := reader.ReadString(' ')
+			require.NoError(t, errRead)
+
+			length, errParse := strconv.Atoi(size[:len(size)-1])
+			require.NoError(t, errParse)
+
+			msg := make([]byte, length)
+			_, errRead = io.ReadFull(reader, msg)
+			require.NoError(t, errRead)
+
+			matches := header.FindStringSubmatch(string(msg))
+			require.Len(t, matches, 5, string(msg))
+			require.Equal(t, "tcp_test", matches[2])
+			require.Contains(t, matches[4], expect)
+		}
+	})
+
+	t.Run("should return error when syslog is not available", func(t *testing.T) {
+		core, err := newSyslogCore(SyslogConfig{Address: "127.0.0.1:1", Network: "tcp"}, NewLogfmtEncoder(zapcore.EncoderConfig{}), "")
+		require.NoError(t, err)
+		require.Equal(t, syslogNilValue, core.appName)
+		require.Error(t, core.Write(zapcore.Entry{Message: "message"}, nil))
+	})
+
+	t.Run("should fail fast until reconnect backoff expires", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		address := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		now := time.Now()
+		out := newSocketWriter("tcp", address)
+		out.now = func() time.Time { return now }
+
+		err = out.write([]byte("first"))
+		require.Error(t, err)
+		require.NotErrorIs(t, err, errSocketBackoff)
+		require.Equal(t, socketMinBackoff, out.backoff)
+
+		// collector is available, but dial is not allowed until backoff expires
+		lis, err = net.Listen("tcp", address)
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, lis.Close()) }()
+
+		require.ErrorIs(t, out.write([]byte("second")), errSocketBackoff)
+
+		now = now.Add(socketMinBackoff)
+		require.NoError(t, out.write([]byte("third")))
+		require.Zero(t, out.backoff)
+		require.NoError(t, out.conn.Close())
+	})
+
+	t.Run("should limit reconnect backoff", func(t *testing.T) {
+		out := newSocketWriter("tcp", "127.0.0.1:1")
+
+		for i := 0; i < 16; i++ {
+			out.fail(time.Now(), io.EOF)
+		}
+
+		require.Equal(t, socketMaxBackoff, out.backoff)
+	})
+
+	t.Run("should map levels to severities", func(t *testing.T) {
+		require.Equal(t, 2, syslogSeverity(zapcore.DPanicLevel))
+		require.Equal(t, 1, syslogSeverity(zapcore.PanicLevel))
+		require.Equal(t, 0, syslogSeverity(zapcore.FatalLevel))
+	})
+}
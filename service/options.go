@@ -1,7 +1,12 @@
 package service
 
 import (
+	"context"
+	"os"
+	"syscall"
 	"time"
+
+	"go.uber.org/zap/zapcore"
 )
 
 // Option allows customizing service module.
@@ -18,6 +23,29 @@ func WithShutdownTimeout(v time.Duration) Option {
 	}
 }
 
+// WithFinalizeTimeout allows set timeout of flushers and final phase (finalizers and logger close).
+func WithFinalizeTimeout(v time.Duration) Option {
+	return func(g *runner) {
+		if v == 0 {
+			return
+		}
+
+		g.finalize = v
+	}
+}
+
+// WithFinalizer allows to call function when all services are stopped (e.g. to flush metrics or close connections),
+// finalizers are called in passed order before logger close.
+func WithFinalizer(name string, fn func(context.Context) error) Option {
+	return func(g *runner) {
+		if fn == nil {
+			return
+		}
+
+		g.finalizers = append(g.finalizers, finalizer{name: name, call: fn})
+	}
+}
+
 // WithLoggerPingPong allows to set ping-pong timer for logger.
 func WithLoggerPingPong(v time.Duration) Option {
 	return func(g *runner) {
@@ -41,6 +69,59 @@ func WithIgnoreError(v error) Option {
 	}
 }
 
+// WithSignalHandler allows to handle signals by custom function,
+// passed signals (e.g. SIGHUP) will not stop services anymore.
+func WithSignalHandler(fn func(os.Signal), signals ...os.Signal) Option {
+	return func(g *runner) {
+		if fn == nil || len(signals) == 0 {
+			return
+		}
+
+		g.handlers = append(g.handlers, signalHandler{handle: fn, signals: signals})
+	}
+}
+
+// WithLoggerReopen allows to reopen logger files on SIGHUP (e.g. after logrotate),
+// SIGHUP will not stop services anymore.
+func WithLoggerReopen() Option {
+	return func(g *runner) {
+		WithSignalHandler(func(os.Signal) {
+			if err := g.logger.Reopen(); err != nil {
+				g.logger.Errorw("could not reopen logger", "error", err)
+			}
+		}, syscall.SIGHUP)(g)
+	}
+}
+
+// WithDebugSignals allows to enable debug level on SIGUSR1 for ttl (15 minutes by default)
+// and to restore permanent levels on SIGUSR2, e.g. when ops server is not reachable.
+// Level changes are logged as audit lines, SIGUSR1 and SIGUSR2 will not stop services.
+func WithDebugSignals(ttl time.Duration) Option {
+	if ttl <= 0 {
+		ttl = defaultDebugTimeout
+	}
+
+	return func(g *runner) {
+		WithSignalHandler(func(sig os.Signal) {
+			levels := g.logger.Levels()
+			previous := levels.Level()
+
+			if sig == syscall.SIGUSR2 {
+				levels.Restore()
+
+				g.logger.Warnw("debug logging disabled", "signal", sig.String(), "previous", previous, "level", levels.Level())
+
+				return
+			}
+
+			levels.SetLevel(zapcore.DebugLevel, ttl)
+
+			g.logger.Warnw("debug logging enabled", "signal", sig.String(), "previous", previous,
+				"level", levels.Level(), "ttl", ttl, "expires", levels.Expires())
+		}, syscall.SIGUSR1, syscall.SIGUSR2)(g)
+	}
+}
+
 func (g *runner) append(v Service) {
 	if svc, ok := v.(Enabler); ok && !svc.Enabled() {
 		g.logger.Warnw("service disabled", "service", v.Name())
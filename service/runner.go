@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/signal"
 	"sync"
 	"syscall"
@@ -21,6 +22,21 @@ type (
 
 		pingPongDisable bool
 		pingPongTimeout time.Duration
+
+		handlers []signalHandler
+
+		finalizers []finalizer
+		finalize   time.Duration
+	}
+
+	finalizer struct {
+		name string
+		call func(context.Context) error
+	}
+
+	signalHandler struct {
+		handle  func(os.Signal)
+		signals []os.Signal
 	}
 
 	// Service interface for component that should be run as goroutine.
@@ -30,6 +46,12 @@ type (
 		Stop(context.Context)
 	}
 
+	// Flusher allows to flush service buffers (e.g. spans of tracer provider),
+	// it is called by runner before services will be stopped.
+	Flusher interface {
+		Flush(context.Context) error
+	}
+
 	// Enabler allows check that service enabled.
 	Enabler interface {
 		Enabled() bool
@@ -48,7 +70,11 @@ type (
 	}
 )
 
-const defaultShutdownTimeout = time.Second * 5
+const (
+	defaultShutdownTimeout = time.Second * 5
+	defaultFinalizeTimeout = time.Second * 5
+	defaultDebugTimeout    = time.Minute * 15
+)
 
 var (
 	_ Runner = (*runner)(nil)
@@ -58,6 +84,13 @@ var (
 		context.Canceled,
 		context.DeadlineExceeded,
 	}
+
+	// nolint:gochecknoglobals
+	defaultShutdownSignals = []os.Signal{
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+	}
 )
 
 // New creates and configures Runner by passed Option's.
@@ -77,6 +110,10 @@ func New(log logger.Logger, options ...Option) Runner {
 		run.shutdown = defaultShutdownTimeout
 	}
 
+	if run.finalize <= 0 {
+		run.finalize = defaultFinalizeTimeout
+	}
+
 	return run
 }
 
@@ -94,14 +131,22 @@ func (g *runner) checkAndIgnore(err error) error {
 // - method blocks until all services will be stopped.
 // - when context will be canceled or deadline exceeded we call shutdown for services.
 // - when the first service (launcher function) returns, all other services will be notified to stop.
+// - before services will be stopped, services are flushed.
+// - when all services are stopped, finalizers are called and logger is closed.
 func (g *runner) Run(parent context.Context) error {
 	if len(g.services) == 0 {
 		return nil
 	}
 
-	ctx, cancel := signal.NotifyContext(parent, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	ctx, cancel := signal.NotifyContext(parent, g.shutdownSignals()...)
 	defer cancel()
 
+	// custom signals are ignored until finalizers will be done, so they could not kill process while stopping
+	defer g.handleSignals(ctx)()
+
+	// finalizers should be called after all services will be stopped
+	defer g.finalizeAll()
+
 	var (
 		err error
 		top context.Context
@@ -153,6 +198,9 @@ func (g *runner) Run(parent context.Context) error {
 		time.Sleep(g.shutdown)
 	}
 
+	// services should be flushed while they are running (e.g. tracer provider is shut down on stop)
+	g.flushAll()
+
 	cancel()
 	g.stopServices(top, err, res)
 
@@ -164,6 +212,122 @@ func (g *runner) Run(parent context.Context) error {
 	return g.checkAndIgnore(err)
 }
 
+// shutdownSignals returns signals that stop services, except signals with custom handlers.
+func (g *runner) shutdownSignals() []os.Signal {
+	out := make([]os.Signal, 0, len(defaultShutdownSignals))
+
+	for _, sig := range defaultShutdownSignals {
+		handled := false
+		for _, handler := range g.handlers {
+			for _, item := range handler.signals {
+				handled = handled || item == sig
+			}
+		}
+
+		if !handled {
+			out = append(out, sig)
+		}
+	}
+
+	return out
+}
+
+// handleSignals calls custom signal handlers until context will be done,
+// after that signals are ignored until returned function will be called.
+func (g *runner) handleSignals(ctx context.Context) func() {
+	done := make(chan struct{})
+	channels := make([]chan os.Signal, 0, len(g.handlers))
+
+	for _, handler := range g.handlers {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, handler.signals...)
+
+		channels = append(channels, ch)
+
+		go func(handler signalHandler) {
+			for {
+				select {
+				case <-done:
+					return
+				case sig := <-ch:
+					if ctx.Err() != nil {
+						g.logger.Infow("ignore signal while stopping", "signal", sig.String())
+
+						continue
+					}
+
+					g.logger.Infow("received signal", "signal", sig.String())
+
+					handler.handle(sig)
+				}
+			}
+		}(handler)
+	}
+
+	return func() {
+		for _, ch := range channels {
+			signal.Stop(ch)
+		}
+
+		close(done)
+	}
+}
+
+// flushAll flushes services, that implement Flusher, in order,
+// all flushes should be done in finalize timeout, the rest of them will be skipped.
+func (g *runner) flushAll() {
+	ctx, cancel := context.WithTimeout(context.Background(), g.finalize)
+	defer cancel()
+
+	for _, svc := range g.services {
+		flusher, ok := svc.(Flusher)
+		if !ok {
+			continue
+		}
+
+		g.logger.Infow("flushing service", "service", svc.Name())
+
+		if err := (finalizer{name: svc.Name(), call: flusher.Flush}).run(ctx); err != nil {
+			g.logger.Errorw("could not flush service", "service", svc.Name(), "error", err)
+		}
+	}
+}
+
+// finalizeAll calls finalizers in order, and then closes logger,
+// all finalizers and logger close should be done in finalize timeout, the rest of them will be skipped.
+func (g *runner) finalizeAll() {
+	ctx, cancel := context.WithTimeout(context.Background(), g.finalize)
+	defer cancel()
+
+	for _, item := range g.finalizers {
+		g.logger.Infow("running finalizer", "finalizer", item.name)
+
+		if err := item.run(ctx); err != nil {
+			g.logger.Errorw("could not finalize", "finalizer", item.name, "error", err)
+		}
+	}
+
+	// logger should be closed the last, so logs of finalizers will be written too
+	_ = finalizer{name: "logger", call: func(context.Context) error { return g.logger.Close() }}.run(ctx)
+}
+
+// run calls finalizer and waits until it will be done or context will be deadlined.
+func (f finalizer) run(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	res := make(chan error, 1)
+	go func() { res <- f.call(ctx) }()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-res:
+		return err
+	}
+}
+
 func (g *runner) stopServices(ctx context.Context, cause error, output <-chan stopper) {
 	// prepare graceful context to stop
 	grace, stop := context.WithTimeout(ctx, g.shutdown)
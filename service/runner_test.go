@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -24,6 +27,12 @@ type (
 
 type fakeSink struct{ io.Writer }
 
+type flusherService struct {
+	Service
+
+	calls *[]string
+}
+
 var errTest = errors.New("test")
 
 var (
@@ -34,8 +43,18 @@ var (
 
 func (f *fakeSink) Close() error { return nil }
 
+func (f flusherService) Flush(context.Context) error {
+	*f.calls = append(*f.calls, f.Name())
+
+	return nil
+}
+
 func (f *fakeSink) Sync() error { return nil }
 
+func (f flusherService) Stop(context.Context) {
+	*f.calls = append(*f.calls, "stopped")
+}
+
 func (stuckService) Name() string { return "stuck-service" }
 
 func (s stuckService) Start(context.Context) error {
@@ -157,13 +176,18 @@ func TestRunner(t *testing.T) {
 		defer cancel()
 
 		svc := newTestService("service-with-enabled-error")
+		log := logger.ForTestsObserved(t)
 
-		grp := New(logger.ForTests(t),
+		grp := New(log,
 			WithService(svc),
 			WithLoggerPingPong(time.Millisecond),
 			WithShutdownTimeout(time.Millisecond))
 
 		require.EqualError(t, errors.Unwrap(grp.Run(ctx)), errTest.Error())
+
+		log.AssertLogged(t, zapcore.InfoLevel, "running service", "service", svc.Name())
+		log.AssertLogged(t, zapcore.ErrorLevel, "received an error", "service", svc.Name())
+		log.AssertLogged(t, zapcore.InfoLevel, "stopping service", "service", svc.Name())
 	})
 
 	t.Run("should run ping-pong service multiple times", func(t *testing.T) {
@@ -235,4 +259,174 @@ func TestRunner(t *testing.T) {
 		require.NoError(t, grp.Run(ctx))
 		require.InDelta(t, time.Since(now), time.Millisecond*5, float64(time.Millisecond*5)) // 5ms lags
 	})
+
+	t.Run("should handle custom signals", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		received := make(chan os.Signal, 2)
+		log := logger.ForTestsObserved(t)
+
+		grp := New(log,
+			WithSignalHandler(nil, syscall.SIGHUP), // should be ignored
+			WithSignalHandler(func(sig os.Signal) { received <- sig }, syscall.SIGHUP, syscall.SIGUSR1),
+			WithShutdownTimeout(time.Millisecond),
+			WithService(NewWorker("signal", func(ctx context.Context) error {
+				// SIGHUP should not stop runner
+				require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+				require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+				var actual []os.Signal
+				for len(actual) < 2 {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case sig := <-received:
+						actual = append(actual, sig)
+					}
+				}
+
+				require.ElementsMatch(t, []os.Signal{syscall.SIGHUP, syscall.SIGUSR1}, actual)
+
+				return nil
+			})))
+
+		require.NoError(t, grp.Run(ctx))
+		require.NoError(t, ctx.Err())
+
+		log.AssertLogged(t, zapcore.InfoLevel, "received signal", "signal", syscall.SIGUSR1.String())
+	})
+
+	t.Run("should ignore custom signals while stopping", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		var handled atomic.Int32
+
+		log := logger.ForTestsObserved(t)
+		grp := New(log,
+			WithSignalHandler(func(os.Signal) { handled.Add(1) }, syscall.SIGHUP),
+			WithShutdownTimeout(time.Millisecond),
+			WithService(newTestService("stopping-enabled")),
+			WithFinalizer("signal", func(context.Context) error {
+				// SIGHUP should not kill process while finalizers are running
+				require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+				require.Eventually(t, func() bool {
+					return len(log.FilterMessage("ignore signal while stopping")) == 1
+				}, time.Second, time.Millisecond)
+
+				return nil
+			}))
+
+		require.NoError(t, grp.Run(ctx))
+		require.Zero(t, handled.Load())
+
+		log.AssertLogged(t, zapcore.InfoLevel, "ignore signal while stopping", "signal", syscall.SIGHUP.String())
+	})
+
+	t.Run("should flush services before stop and call finalizers in order", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		var calls []string
+
+		log := logger.ForTestsObserved(t)
+		grp := New(log,
+			WithService(flusherService{Service: newTestService("flusher-enabled"), calls: &calls}),
+			WithShutdownTimeout(time.Millisecond),
+			WithFinalizeTimeout(0),    // should be ignored
+			WithFinalizer("nil", nil), // should be ignored
+			WithFinalizer("first", func(context.Context) error {
+				calls = append(calls, "first")
+
+				return nil
+			}),
+			WithFinalizer("second", func(context.Context) error {
+				calls = append(calls, "second")
+
+				return errTest
+			}))
+
+		require.NoError(t, grp.Run(ctx))
+		// services should be flushed before they will be stopped
+		require.Equal(t, []string{"flusher-enabled", "stopped", "first", "second"}, calls)
+
+		log.AssertLogged(t, zapcore.InfoLevel, "flushing service", "service", "flusher-enabled")
+		log.AssertLogged(t, zapcore.InfoLevel, "running finalizer", "finalizer", "first")
+		log.AssertLogged(t, zapcore.ErrorLevel, "could not finalize", "finalizer", "second", "error", errTest)
+	})
+
+	t.Run("should skip finalizers when finalize timeout exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		stuck := make(chan struct{})
+		defer close(stuck)
+
+		now := time.Now()
+		log := logger.ForTestsObserved(t)
+		grp := New(log,
+			WithService(newTestService("test-service-enabled")),
+			WithShutdownTimeout(time.Millisecond),
+			WithFinalizeTimeout(time.Millisecond*10),
+			WithFinalizer("stuck", func(context.Context) error {
+				<-stuck
+
+				return nil
+			}),
+			WithFinalizer("skipped", func(context.Context) error {
+				t.Error("finalizer should be skipped")
+
+				return nil
+			}))
+
+		require.NoError(t, grp.Run(ctx))
+		require.Less(t, time.Since(now), time.Millisecond*100)
+
+		log.AssertLogged(t, zapcore.ErrorLevel, "could not finalize", "finalizer", "stuck", "error", context.DeadlineExceeded)
+		log.AssertLogged(t, zapcore.ErrorLevel, "could not finalize", "finalizer", "skipped", "error", context.DeadlineExceeded)
+	})
+
+	t.Run("should reopen logger on SIGHUP", func(t *testing.T) {
+		grp, ok := New(logger.ForTests(t), WithLoggerReopen()).(*runner)
+		require.True(t, ok)
+		require.Len(t, grp.handlers, 1)
+		require.Equal(t, []os.Signal{syscall.SIGINT, syscall.SIGTERM}, grp.shutdownSignals())
+		require.NotPanics(t, func() { grp.handlers[0].handle(syscall.SIGHUP) })
+	})
+
+	t.Run("should toggle debug level on SIGUSR1 and SIGUSR2", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		log.Levels().SetLevel(zapcore.InfoLevel, 0)
+
+		grp, ok := New(log, WithDebugSignals(0)).(*runner)
+		require.True(t, ok)
+		require.Len(t, grp.handlers, 1)
+		require.Equal(t, []os.Signal{syscall.SIGUSR1, syscall.SIGUSR2}, grp.handlers[0].signals)
+
+		now := time.Now()
+		grp.handlers[0].handle(syscall.SIGUSR1)
+		require.Equal(t, zapcore.DebugLevel, log.Levels().Level())
+		require.WithinDuration(t, now.Add(defaultDebugTimeout), log.Levels().Expires(), time.Second)
+		log.AssertLogged(t, zapcore.WarnLevel, "debug logging enabled",
+			"signal", syscall.SIGUSR1.String(), "previous", zapcore.InfoLevel, "level", zapcore.DebugLevel, "ttl", defaultDebugTimeout)
+
+		grp.handlers[0].handle(syscall.SIGUSR2)
+		require.Equal(t, zapcore.InfoLevel, log.Levels().Level())
+		require.True(t, log.Levels().Expires().IsZero())
+		log.AssertLogged(t, zapcore.WarnLevel, "debug logging disabled",
+			"signal", syscall.SIGUSR2.String(), "previous", zapcore.DebugLevel, "level", zapcore.InfoLevel)
+	})
+
+	t.Run("should restore level after debug ttl", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		log.Levels().SetLevel(zapcore.WarnLevel, 0)
+
+		grp, ok := New(log, WithDebugSignals(time.Millisecond*10)).(*runner)
+		require.True(t, ok)
+
+		grp.handlers[0].handle(syscall.SIGUSR1)
+		require.Equal(t, zapcore.DebugLevel, log.Levels().Level())
+		require.Eventually(t, func() bool { return log.Levels().Level() == zapcore.WarnLevel }, time.Second, time.Millisecond)
+	})
 }
@@ -1,6 +1,7 @@
 package tracer
 
 import (
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/im-kulikov/go-bones"
@@ -10,7 +11,7 @@ import (
 
 // Config provides configuration for jaeger tracer.
 type Config struct {
-	Type    Type `env:"TYPE" default:"jaeger" usage:"allows to set trace exporter type"`
+	Type    Type `env:"TYPE" default:"jaeger" enum:"jaeger" usage:"allows to set trace exporter type"`
 	Enabled bool `env:"ENABLED" default:"false" usage:"allows to enable tracing"`
 
 	Jaeger
@@ -25,6 +26,9 @@ type SpanProcessor = trace.SpanProcessor
 // JaegerType allows use jaeger as tracer.
 const JaegerType = Type("jaeger")
 
+// otelLoggerName is a name of logger, that used for OpenTelemetry internal diagnostics.
+const otelLoggerName = "otel"
+
 var errUnknownType = bones.Error{
 	Code:    bones.ErrorCodeInternal,
 	Message: "unknown tracer type",
@@ -36,6 +40,9 @@ func Init(log logger.Logger, cfg Config, opts ...Option) (service.Service, error
 		return nil, nil
 	}
 
+	// OpenTelemetry internal diagnostics should be written by our logger
+	otel.SetLogger(log.Named(otelLoggerName).Logr())
+
 	switch cfg.Type {
 	case JaegerType:
 		return prepareJaeger(log, cfg.Jaeger, opts...)
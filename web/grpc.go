@@ -7,6 +7,7 @@ import (
 	gprom "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/grpclog"
 
 	"github.com/im-kulikov/go-bones/logger"
 	"github.com/im-kulikov/go-bones/service"
@@ -17,7 +18,7 @@ type GRPCConfig struct {
 	Enabled bool   `env:"ENABLED" default:"false" usage:"allows to enable grpc server"`
 	Reflect bool   `env:"REFLECT" default:"false" usage:"allows to enable grpc reflection service"`
 	Address string `env:"ADDRESS" default:":9080" usage:"gRPC server listen address"`
-	Network string `env:"NETWORK" default:"tcp" usage:"gRPC server listen network: tpc/udp"`
+	Network string `env:"NETWORK" default:"tcp" enum:"tcp,tcp4,tcp6,unix" usage:"gRPC server listen network"`
 }
 
 type gRPCServer struct {
@@ -29,10 +30,16 @@ type gRPCServer struct {
 	services []GRPCService
 }
 
-func defaultGRPCServer() *grpc.Server {
+func defaultGRPCServer(log logger.Logger) *grpc.Server {
 	return grpc.NewServer(
-		grpc.ChainUnaryInterceptor(gprom.UnaryServerInterceptor, otelgrpc.UnaryServerInterceptor()),
-		grpc.ChainStreamInterceptor(gprom.StreamServerInterceptor, otelgrpc.StreamServerInterceptor()),
+		grpc.ChainUnaryInterceptor(
+			gprom.UnaryServerInterceptor,
+			otelgrpc.UnaryServerInterceptor(),
+			GRPCLoggerUnaryInterceptor(log)),
+		grpc.ChainStreamInterceptor(
+			gprom.StreamServerInterceptor,
+			otelgrpc.StreamServerInterceptor(),
+			GRPCLoggerStreamInterceptor(log)),
 	)
 }
 
@@ -40,14 +47,22 @@ const (
 	defaultGRPCName    = "grpc-server"
 	defaultGRPCAddress = ":9080"
 	defaultGRPCNetwork = "tcp"
+
+	// grpcLoggerName is a name of logger, that used for gRPC internal diagnostics.
+	grpcLoggerName = "grpc"
 )
 
+// InitGRPCLogger replaces gRPC internal logger with `grpc` named logger, so its level could be changed by named levels.
+// grpclog.SetLoggerV2 is not thread-safe, so it should be called once before any gRPC functions (e.g. at the start of main).
+func InitGRPCLogger(log logger.Logger) {
+	grpclog.SetLoggerV2(log.Named(grpcLoggerName).GRPCLogger())
+}
+
 // NewGRPCServer creates new gRPC server and implements service.Service interface.
 func NewGRPCServer(opts ...GRPCOption) service.Service {
 	serve := &gRPCServer{
 		name:   defaultGRPCName,
 		logger: logger.Default(),
-		server: defaultGRPCServer(),
 
 		GRPCConfig: GRPCConfig{
 			Enabled: true,
@@ -60,6 +75,11 @@ func NewGRPCServer(opts ...GRPCOption) service.Service {
 		o(serve)
 	}
 
+	// default server is created after options, so it could use custom logger
+	if serve.server == nil {
+		serve.server = defaultGRPCServer(serve.logger)
+	}
+
 	if serve.Reflect {
 		serve.services = append(serve.services, new(reflectionService))
 	}
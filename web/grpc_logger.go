@@ -0,0 +1,34 @@
+package web
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+type loggerServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns stream context that contains logger.
+func (s *loggerServerStream) Context() context.Context { return s.ctx }
+
+// GRPCLoggerUnaryInterceptor puts request-scoped logger.Logger with trace fields into request context,
+// so handlers could use logger.FromContext to log with trace fields.
+func GRPCLoggerUnaryInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(logger.WithTraceContext(ctx, log), req)
+	}
+}
+
+// GRPCLoggerStreamInterceptor puts request-scoped logger.Logger with trace fields into stream context,
+// so handlers could use logger.FromContext to log with trace fields.
+func GRPCLoggerStreamInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &loggerServerStream{ServerStream: stream, ctx: logger.WithTraceContext(stream.Context(), log)})
+	}
+}
@@ -0,0 +1,42 @@
+package web
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+}
+
+func (testServerStream) Context() context.Context { return context.Background() }
+
+func TestGRPCLoggerInterceptors(t *testing.T) {
+	log := logger.ForTests(t)
+
+	t.Run("unary", func(t *testing.T) {
+		res, err := GRPCLoggerUnaryInterceptor(log)(context.Background(), "request", nil,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				require.Equal(t, log, logger.FromContext(ctx))
+
+				return req, nil
+			})
+
+		require.NoError(t, err)
+		require.Equal(t, "request", res)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		require.NoError(t, GRPCLoggerStreamInterceptor(log)(nil, testServerStream{}, nil,
+			func(_ interface{}, stream grpc.ServerStream) error {
+				require.Equal(t, log, logger.FromContext(stream.Context()))
+
+				return nil
+			}))
+	})
+}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/grpclog"
 	reflection "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"github.com/im-kulikov/go-bones/logger"
@@ -21,6 +23,18 @@ import (
 
 const testGRPCServiceName = "grpc-test"
 
+func TestInitGRPCLogger(t *testing.T) {
+	log := logger.ForTestsObserved(t)
+
+	InitGRPCLogger(log)
+	grpclog.Warning("transport is closing")
+
+	entries := log.FilterMessage("transport is closing")
+	require.Len(t, entries, 1)
+	require.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	require.Equal(t, grpcLoggerName, entries[0].LoggerName)
+}
+
 func TestNewGRPCServer(t *testing.T) {
 	lis, errListen := net.Listen(defaultGRPCNetwork, "127.0.0.1:0")
 	require.NoError(t, errListen)
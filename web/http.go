@@ -14,8 +14,10 @@ import (
 type HTTPConfig struct {
 	Enabled bool   `env:"ENABLED" default:"false" usage:"allows to enable http server"`
 	Address string `env:"ADDRESS" default:":8080" usage:"HTTP server listen address"`
-	Network string `env:"NETWORK" default:"tcp" usage:"HTTP server listen network: tpc/udp"`
+	Network string `env:"NETWORK" default:"tcp" enum:"tcp,tcp4,tcp6,unix" usage:"HTTP server listen network"`
 	NoTrace bool   `env:"NO_TRACE" default:"false" usage:"allows to disable tracing for HTTP server"`
+
+	AccessLog AccessLogConfig `env:"ACCESS_LOG"`
 }
 
 type httpServer struct {
@@ -65,9 +67,10 @@ func (s *httpServer) Start(ctx context.Context) error {
 		return err
 	}
 
-	handler := s.handle
+	// access log is written inside of tracing middleware, so entries contain trace fields
+	handler := HTTPAccessLogMiddleware(HTTPLoggerMiddleware(s.handle, s.logger), s.logger, s.AccessLog)
 	if !s.NoTrace {
-		handler = HTTPTracingMiddleware(s.handle)
+		handler = HTTPTracingMiddleware(handler)
 	}
 
 	s.server = &http.Server{
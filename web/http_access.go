@@ -0,0 +1,194 @@
+package web
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+// AccessLogConfig provides configuration of http access log.
+type AccessLogConfig struct {
+	Enabled    bool          `env:"ENABLED" default:"false" usage:"allows to enable access log"`
+	Skip       string        `env:"SKIP" usage:"allows to skip paths (* suffix matches prefix)"`
+	Slow       time.Duration `env:"SLOW" default:"1s" usage:"allows to log slower requests with warn level (0 disables)"`
+	SampleRate int           `env:"SAMPLE_RATE" default:"1" usage:"allows to log every N-th successful (2xx) request"`
+}
+
+type (
+	accessLogger struct {
+		log     logger.Logger
+		cfg     AccessLogConfig
+		skip    []string
+		counter atomic.Uint64
+	}
+
+	// accessWriter records status code and count of written bytes,
+	// it implements http.Flusher and http.Hijacker, when wrapped writer supports them.
+	accessWriter struct {
+		http.ResponseWriter
+
+		status int
+		bytes  int
+	}
+)
+
+const (
+	// accessLogName is a name of access logger, so its level could be changed by named levels.
+	accessLogName = "http.access"
+
+	// requestIDHeader is a header of request identifier, that is propagated by clients and proxies.
+	requestIDHeader = "X-Request-ID"
+)
+
+// HTTPAccessLogMiddleware logs method, path, status, bytes, duration, remote address, user agent,
+// request (X-Request-ID header) and trace identifiers of each request by `http.access` logger:
+// - requests with skipped paths (e.g. health checks and metrics) are not logged
+// - server errors (5xx) are logged with error level, slow requests are logged with warn level
+// - requests with panicked handlers are logged with http.StatusInternalServerError status
+// - only every N-th successful (2xx) request is logged, when sample rate is greater than one.
+func HTTPAccessLogMiddleware(handler http.Handler, log logger.Logger, cfg AccessLogConfig) http.Handler {
+	if !cfg.Enabled || log == nil {
+		return handler
+	}
+
+	access := &accessLogger{log: log.Named(accessLogName), cfg: cfg}
+	for _, item := range strings.Split(cfg.Skip, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			access.skip = append(access.skip, item)
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if access.skipped(r.URL.Path) {
+			handler.ServeHTTP(w, r)
+
+			return
+		}
+
+		start := time.Now()
+		writer := &accessWriter{ResponseWriter: w}
+
+		// request is logged even when handler panics, panic is propagated to the server as is
+		completed := false
+		defer func() {
+			if !completed {
+				writer.status = http.StatusInternalServerError
+			}
+
+			access.write(r, writer, time.Since(start))
+		}()
+
+		handler.ServeHTTP(writer, r)
+
+		completed = true
+	})
+}
+
+func (a *accessLogger) skipped(path string) bool {
+	for _, item := range a.skip {
+		if prefix, ok := strings.CutSuffix(item, "*"); (ok && strings.HasPrefix(path, prefix)) || path == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sampled reports whether successful request should be logged.
+func (a *accessLogger) sampled() bool {
+	return a.cfg.SampleRate <= 1 || (a.counter.Add(1)-1)%uint64(a.cfg.SampleRate) == 0
+}
+
+func (a *accessLogger) write(r *http.Request, w *accessWriter, duration time.Duration) {
+	status := w.statusCode()
+	slow := a.cfg.Slow > 0 && duration >= a.cfg.Slow
+
+	if status >= http.StatusOK && status < http.StatusMultipleChoices && !slow && !a.sampled() {
+		return
+	}
+
+	fields := []interface{}{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", status,
+		"bytes", w.bytes,
+		"duration", duration,
+		"remote", r.RemoteAddr,
+		"user_agent", r.UserAgent(),
+	}
+
+	if id := r.Header.Get(requestIDHeader); id != "" {
+		fields = append(fields, "request_id", id)
+	}
+
+	if slow {
+		fields = append(fields, "slow", true)
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		a.log.ErrorwCtx(r.Context(), "http request", fields...)
+	case slow:
+		a.log.WarnwCtx(r.Context(), "http request", fields...)
+	default:
+		a.log.InfowCtx(r.Context(), "http request", fields...)
+	}
+}
+
+// WriteHeader records status code.
+func (w *accessWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records count of written bytes.
+func (w *accessWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += n
+
+	return n, err
+}
+
+// Flush sends buffered data to the client, when wrapped writer supports it.
+func (w *accessWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack allows handler to take over the connection (e.g. websockets), when wrapped writer supports it,
+// hijacked requests are logged with http.StatusSwitchingProtocols status, when status was not written.
+func (w *accessWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
+// Unwrap returns wrapped http.ResponseWriter, so http.ResponseController could flush response.
+func (w *accessWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// statusCode returns written status code, http.StatusOK is written when handler writes nothing.
+func (w *accessWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
@@ -0,0 +1,194 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+func TestHTTPAccessLogMiddleware(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(time.Millisecond * 20)
+		case "/not-found":
+			http.NotFound(w, r)
+
+			return
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	cfg := AccessLogConfig{Enabled: true, Skip: "/healthy, /debug/*", Slow: time.Millisecond * 10, SampleRate: 1}
+
+	serve := func(log logger.Logger, cfg AccessLogConfig, path string, header ...string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+
+		HTTPAccessLogMiddleware(handler, log, cfg).ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	cases := []struct {
+		name   string
+		path   string
+		level  zapcore.Level
+		status int
+		bytes  int
+		skip   bool
+	}{
+		{name: "should log successful request", path: "/users", level: zapcore.InfoLevel, status: http.StatusOK, bytes: 5},
+		{name: "should log client error", path: "/not-found", level: zapcore.InfoLevel, status: http.StatusNotFound, bytes: 19},
+		{name: "should log server error", path: "/fail", level: zapcore.ErrorLevel, status: http.StatusInternalServerError},
+		{name: "should log slow request", path: "/slow", level: zapcore.WarnLevel, status: http.StatusOK, bytes: 5},
+		{name: "should skip health checks", path: "/healthy", skip: true},
+		{name: "should skip path prefix", path: "/debug/pprof", skip: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			log := logger.ForTestsObserved(t)
+			serve(log, cfg, tt.path, "User-Agent", "test-agent", requestIDHeader, "request-id")
+
+			entries := log.FilterMessage("http request")
+			if tt.skip {
+				require.Empty(t, entries)
+
+				return
+			}
+
+			require.Len(t, entries, 1)
+			require.Equal(t, accessLogName, entries[0].LoggerName)
+			require.Equal(t, tt.level, entries[0].Level)
+
+			log.AssertLogged(t, tt.level, "http request",
+				"method", http.MethodGet,
+				"path", tt.path,
+				"status", tt.status,
+				"bytes", tt.bytes,
+				"remote", "192.0.2.1:1234",
+				"user_agent", "test-agent",
+				"request_id", "request-id")
+
+			_, slow := entries[0].ContextMap()["slow"]
+			require.Equal(t, tt.level == zapcore.WarnLevel, slow)
+		})
+	}
+
+	t.Run("should sample successful requests", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		sampled := HTTPAccessLogMiddleware(handler, log, AccessLogConfig{Enabled: true, SampleRate: 3})
+
+		for _, path := range []string{"/users", "/users", "/users", "/fail", "/users", "/not-found"} {
+			sampled.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+
+		entries := log.FilterMessage("http request")
+		require.Len(t, entries, 4)
+
+		var paths []interface{}
+		for _, entry := range entries {
+			paths = append(paths, entry.ContextMap()["path"])
+		}
+
+		require.Equal(t, []interface{}{"/users", "/fail", "/users", "/not-found"}, paths)
+	})
+
+	t.Run("should log trace fields", func(t *testing.T) {
+		span := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		})
+
+		log := logger.ForTestsObserved(t)
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req = req.WithContext(trace.ContextWithSpanContext(req.Context(), span))
+
+		HTTPAccessLogMiddleware(handler, log, cfg).ServeHTTP(httptest.NewRecorder(), req)
+
+		log.AssertLogged(t, zapcore.InfoLevel, "http request", logger.TraceIDKey, span.TraceID().String())
+	})
+
+	t.Run("should not skip paths by default", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		serve(log, AccessLogConfig{Enabled: true}, "/healthy")
+		require.Len(t, log.FilterMessage("http request"), 1)
+	})
+
+	t.Run("should flush response", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		rec := httptest.NewRecorder()
+
+		HTTPAccessLogMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			require.True(t, ok)
+
+			flusher.Flush()
+		}), log, AccessLogConfig{Enabled: true}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+		require.True(t, rec.Flushed)
+		log.AssertLogged(t, zapcore.InfoLevel, "http request", "path", "/events", "status", http.StatusOK)
+	})
+
+	t.Run("should hijack connection", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		srv := httptest.NewServer(HTTPAccessLogMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+
+			defer func() { require.NoError(t, conn.Close()) }()
+
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: close\r\n\r\n")
+			require.NoError(t, rw.Flush())
+		}), log, AccessLogConfig{Enabled: true}))
+		defer srv.Close()
+
+		res, err := srv.Client().Get(srv.URL + "/ws")
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+		// entry is written after handler returns, so client could receive response earlier
+		require.Eventually(t, func() bool { return len(log.FilterMessage("http request")) == 1 }, time.Second, time.Millisecond)
+		log.AssertLogged(t, zapcore.InfoLevel, "http request", "path", "/ws", "status", http.StatusSwitchingProtocols)
+	})
+
+	t.Run("should log panicked request", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		panicked := HTTPAccessLogMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+
+			panic("boom")
+		}), log, AccessLogConfig{Enabled: true})
+
+		require.PanicsWithValue(t, "boom", func() {
+			panicked.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+		})
+
+		log.AssertLogged(t, zapcore.ErrorLevel, "http request", "path", "/panic", "status", http.StatusInternalServerError)
+	})
+
+	t.Run("should fail to hijack unsupported writer", func(t *testing.T) {
+		_, _, err := (&accessWriter{ResponseWriter: httptest.NewRecorder()}).Hijack()
+		require.ErrorIs(t, err, http.ErrNotSupported)
+	})
+
+	t.Run("should not wrap handler when disabled", func(t *testing.T) {
+		log := logger.ForTestsObserved(t)
+		serve(log, AccessLogConfig{}, "/users")
+		require.Empty(t, log.All())
+	})
+}
@@ -0,0 +1,15 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+// HTTPLoggerMiddleware puts request-scoped logger.Logger with trace fields into request context,
+// so handlers could use logger.FromContext to log with trace fields.
+func HTTPLoggerMiddleware(handler http.Handler, log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(logger.WithTraceContext(r.Context(), log)))
+	})
+}
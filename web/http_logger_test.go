@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+func TestHTTPLoggerMiddleware(t *testing.T) {
+	log := logger.ForTests(t)
+
+	handler := HTTPLoggerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, log, logger.FromContext(r.Context()))
+
+		w.WriteHeader(http.StatusNoContent)
+	}), log)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	t.Run("should store request-scoped logger", func(t *testing.T) {
+		span := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{1},
+		})
+
+		handler = HTTPLoggerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NotEqual(t, log, logger.FromContext(r.Context()))
+
+			w.WriteHeader(http.StatusNoContent)
+		}), log)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(trace.ContextWithSpanContext(req.Context(), span))
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
@@ -0,0 +1,117 @@
+package web
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Listener describes listen settings of the server.
+type Listener struct {
+	Enabled bool
+	Network string
+	Address string
+}
+
+// ListenerConfig implemented by server configs, that listen network address.
+type ListenerConfig interface {
+	Listener() Listener
+}
+
+const (
+	networkTCP  = "tcp"
+	networkTCP4 = "tcp4"
+	networkTCP6 = "tcp6"
+	networkUnix = "unix"
+)
+
+var (
+	_ ListenerConfig = OpsConfig{}
+	_ ListenerConfig = HTTPConfig{}
+	_ ListenerConfig = GRPCConfig{}
+
+	// nolint:gochecknoglobals
+	listenNetworks = []string{networkTCP, networkTCP4, networkTCP6, networkUnix}
+)
+
+// Listener returns listen settings of ops server.
+func (o OpsConfig) Listener() Listener {
+	return Listener{Enabled: o.Enabled, Network: o.Network, Address: o.Address}
+}
+
+// Listener returns listen settings of http server.
+func (c HTTPConfig) Listener() Listener {
+	return Listener{Enabled: c.Enabled, Network: c.Network, Address: c.Address}
+}
+
+// Listener returns listen settings of gRPC server.
+func (c GRPCConfig) Listener() Listener {
+	return Listener{Enabled: c.Enabled, Network: c.Network, Address: c.Address}
+}
+
+// Normalize checks listen network and returns Listener with normalized address:
+// - unspecified hosts (empty, 0.0.0.0, ::) are converted to empty host;
+// - named ports are converted to numbers;
+// - unix socket paths are cleaned.
+func (l Listener) Normalize() (Listener, error) {
+	switch l.Network {
+	case networkUnix:
+		if l.Address == "" {
+			return l, fmt.Errorf("empty unix socket path")
+		}
+
+		l.Address = filepath.Clean(l.Address)
+
+		return l, nil
+	case networkTCP, networkTCP4, networkTCP6:
+	default:
+		return l, fmt.Errorf("unsupported network %q, expected one of: %s",
+			l.Network, strings.Join(listenNetworks, ", "))
+	}
+
+	host, port, err := net.SplitHostPort(l.Address)
+	if err != nil {
+		return l, err
+	}
+
+	num, err := net.LookupPort(l.Network, port)
+	if err != nil {
+		return l, err
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+
+		if ip.IsUnspecified() {
+			host = ""
+		}
+	}
+
+	l.Address = net.JoinHostPort(host, strconv.Itoa(num))
+
+	return l, nil
+}
+
+// Conflicts reports whether normalized listeners could not be bound at the same time.
+func (l Listener) Conflicts(o Listener) bool {
+	if l.Network == networkUnix || o.Network == networkUnix {
+		return l.Network == o.Network && l.Address == o.Address
+	}
+
+	// IPv4-only and IPv6-only sockets could share the same port.
+	if l.Network != o.Network && l.Network != networkTCP && o.Network != networkTCP {
+		return false
+	}
+
+	lHost, lPort, _ := net.SplitHostPort(l.Address)
+	oHost, oPort, _ := net.SplitHostPort(o.Address)
+
+	// zero port means that system chooses free port.
+	if lPort != oPort || lPort == "0" {
+		return false
+	}
+
+	return lHost == "" || oHost == "" || lHost == oHost
+}
@@ -0,0 +1,131 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener_Normalize(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  Listener
+		expect Listener
+		error  string
+	}{
+		{
+			name:   "should clean empty host",
+			input:  Listener{Network: "tcp", Address: ":8080"},
+			expect: Listener{Network: "tcp", Address: ":8080"},
+		},
+		{
+			name:   "should convert unspecified IPv4 host",
+			input:  Listener{Network: "tcp4", Address: "0.0.0.0:8080"},
+			expect: Listener{Network: "tcp4", Address: ":8080"},
+		},
+		{
+			name:   "should convert unspecified IPv6 host",
+			input:  Listener{Network: "tcp6", Address: "[::]:8080"},
+			expect: Listener{Network: "tcp6", Address: ":8080"},
+		},
+		{
+			name:   "should keep specified host",
+			input:  Listener{Network: "tcp", Address: "127.0.0.1:8080"},
+			expect: Listener{Network: "tcp", Address: "127.0.0.1:8080"},
+		},
+		{
+			name:   "should clean unix socket path",
+			input:  Listener{Network: "unix", Address: "/tmp/../tmp/app.sock"},
+			expect: Listener{Network: "unix", Address: "/tmp/app.sock"},
+		},
+		{
+			name:  "should fail on udp network",
+			input: Listener{Network: "udp", Address: ":8080"},
+			error: `unsupported network "udp", expected one of: tcp, tcp4, tcp6, unix`,
+		},
+		{
+			name:  "should fail on empty unix socket path",
+			input: Listener{Network: "unix"},
+			error: "empty unix socket path",
+		},
+		{
+			name:  "should fail on address without port",
+			input: Listener{Network: "tcp", Address: "localhost"},
+			error: "address localhost: missing port in address",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.input.Normalize()
+			if tt.error != "" {
+				require.EqualError(t, err, tt.error)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestListener_Conflicts(t *testing.T) {
+	cases := []struct {
+		name   string
+		one    Listener
+		two    Listener
+		expect bool
+	}{
+		{
+			name:   "same port with empty hosts",
+			one:    Listener{Network: "tcp", Address: ":8080"},
+			two:    Listener{Network: "tcp", Address: ":8080"},
+			expect: true,
+		},
+		{
+			name:   "same port with unspecified and specified host",
+			one:    Listener{Network: "tcp", Address: ":8080"},
+			two:    Listener{Network: "tcp4", Address: "127.0.0.1:8080"},
+			expect: true,
+		},
+		{
+			name: "same port with different hosts",
+			one:  Listener{Network: "tcp", Address: "127.0.0.1:8080"},
+			two:  Listener{Network: "tcp", Address: "127.0.0.2:8080"},
+		},
+		{
+			name: "different ports",
+			one:  Listener{Network: "tcp", Address: ":8080"},
+			two:  Listener{Network: "tcp", Address: ":8081"},
+		},
+		{
+			name: "zero ports",
+			one:  Listener{Network: "tcp", Address: ":0"},
+			two:  Listener{Network: "tcp", Address: ":0"},
+		},
+		{
+			name: "IPv4-only and IPv6-only",
+			one:  Listener{Network: "tcp4", Address: ":8080"},
+			two:  Listener{Network: "tcp6", Address: ":8080"},
+		},
+		{
+			name:   "same unix socket",
+			one:    Listener{Network: "unix", Address: "/tmp/app.sock"},
+			two:    Listener{Network: "unix", Address: "/tmp/app.sock"},
+			expect: true,
+		},
+		{
+			name: "unix socket and tcp",
+			one:  Listener{Network: "unix", Address: "/tmp/app.sock"},
+			two:  Listener{Network: "tcp", Address: ":8080"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, tt.one.Conflicts(tt.two))
+			require.Equal(t, tt.expect, tt.two.Conflicts(tt.one))
+		})
+	}
+}
@@ -23,12 +23,14 @@ import (
 type OpsConfig struct {
 	Enabled bool   `env:"ENABLED" default:"false" usage:"allows to enable ops server"`
 	Address string `env:"ADDRESS" default:":8081" usage:"allows to set set ops address:port"`
-	Network string `env:"NETWORK" default:"tcp" usage:"allows to set ops listen network: tcp/udp"`
+	Network string `env:"NETWORK" default:"tcp" enum:"tcp,tcp4,tcp6,unix" usage:"allows to set ops listen network"`
 	NoTrace bool   `env:"NO_TRACE" default:"true" usage:"allows to disable tracing"`
 
-	MetricsPath string `env:"METRICS_PATH" default:"/metrics" usage:"allows to set custom metrics path"`
-	HealthyPath string `env:"HEALTHY_PATH" default:"/healthy" usage:"allows to set custom healthy path"`
-	ProfilePath string `env:"PROFILE_PATH" default:"/debug/pprof" usage:"allows to set custom profiler path"`
+	MetricsPath  string `env:"METRICS_PATH" default:"/metrics" usage:"allows to set custom metrics path"`
+	HealthyPath  string `env:"HEALTHY_PATH" default:"/healthy" usage:"allows to set custom healthy path"`
+	ProfilePath  string `env:"PROFILE_PATH" default:"/debug/pprof" usage:"allows to set custom profiler path"`
+	LogLevelPath string `env:"LOG_LEVEL_PATH" default:"/log/level" usage:"allows to set custom logger level path"`
+	LogsPath     string `env:"LOGS_PATH" default:"/debug/logs" usage:"allows to set custom recent logs path (logger buffer should be enabled)"`
 }
 
 // opsWorker implements service.Service
@@ -86,6 +88,16 @@ func NewOpsServer(log logger.Logger, cfg OpsConfig, list ...HealthChecker) servi
 	// metrics
 	mux.Handle(cfg.MetricsPath, promhttp.Handler())
 
+	// runtime logger level
+	if levels := newLogLevelHandler(log); cfg.LogLevelPath != "" && levels != nil {
+		mux.Handle(cfg.LogLevelPath, levels)
+	}
+
+	// recent logs kept by logger buffer
+	if logs := newLogsHandler(log); cfg.LogsPath != "" && logs != nil {
+		mux.Handle(cfg.LogsPath, logs)
+	}
+
 	return service.NewGroup(opsServiceName,
 		wrk, NewHTTPServer(
 			cfg.httpOption(),
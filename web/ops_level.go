@@ -0,0 +1,131 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+type (
+	logLevelHandler struct {
+		log logger.Logger
+	}
+
+	// logLevelRequest describes PUT request body, e.g. `{"level":"debug","ttl":"10m"}`,
+	// when logger name is passed, level is applied to loggers with matched name prefix.
+	logLevelRequest struct {
+		Logger string `json:"logger,omitempty"`
+		Level  string `json:"level"`
+		TTL    string `json:"ttl,omitempty"`
+	}
+
+	logLevelState struct {
+		Level   string     `json:"level"`
+		Expires *time.Time `json:"expires,omitempty"`
+	}
+
+	logLevelResponse struct {
+		logLevelState
+
+		Loggers map[string]logLevelState `json:"loggers,omitempty"`
+	}
+)
+
+// maxLogLevelRequestSize limits PUT request body, the request is tiny, so 1KiB is more than enough.
+const maxLogLevelRequestSize = 1 << 10
+
+func newLogLevelHandler(log logger.Logger) http.Handler {
+	if log == nil || log.Levels() == nil {
+		return nil
+	}
+
+	return &logLevelHandler{log: log}
+}
+
+// ServeHTTP allows to get (GET) and change (PUT) logger level at runtime.
+func (h *logLevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := h.update(w, r); err != nil {
+			h.reply(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+
+			return
+		}
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+		h.reply(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+
+		return
+	}
+
+	levels := h.log.Levels()
+	res := logLevelResponse{logLevelState: newLogLevelState(levels.Level(), levels.Expires())}
+
+	for name, state := range levels.Named() {
+		if res.Loggers == nil {
+			res.Loggers = make(map[string]logLevelState)
+		}
+
+		res.Loggers[name] = newLogLevelState(state.Level, state.Expires)
+	}
+
+	h.reply(w, http.StatusOK, res)
+}
+
+func newLogLevelState(level zapcore.Level, expires time.Time) logLevelState {
+	out := logLevelState{Level: level.String()}
+	if !expires.IsZero() {
+		out.Expires = &expires
+	}
+
+	return out
+}
+
+func (h *logLevelHandler) update(w http.ResponseWriter, r *http.Request) error {
+	var req logLevelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLogLevelRequestSize)).Decode(&req); err != nil {
+		return fmt.Errorf("could not decode request: %w", err)
+	}
+
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+			return fmt.Errorf("invalid ttl %q", req.TTL)
+		}
+	}
+
+	if req.Logger != "" {
+		h.log.Levels().SetNamedLevel(req.Logger, level, ttl)
+	} else {
+		h.log.Levels().SetLevel(level, ttl)
+	}
+
+	h.log.Warnw("logger level changed",
+		"logger", req.Logger,
+		"level", level.String(),
+		"ttl", ttl.String(),
+		"remote", r.RemoteAddr)
+
+	return nil
+}
+
+func (h *logLevelHandler) reply(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.log.Errorf("could not write response: %v", err)
+	}
+}
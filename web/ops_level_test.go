@@ -0,0 +1,78 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	log := logger.ForTests(t)
+	handler := newLogLevelHandler(log)
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/log/level", strings.NewReader(body)))
+
+		return rec
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		body   string
+		code   int
+		expect string
+	}{
+		{name: "get level", method: http.MethodGet, code: http.StatusOK, expect: `{"level":"debug"}`},
+		{name: "set level", method: http.MethodPut, body: `{"level":"warn"}`, code: http.StatusOK, expect: `{"level":"warn"}`},
+		{
+			name:   "set named level",
+			method: http.MethodPut,
+			body:   `{"logger":"grpc","level":"error"}`,
+			code:   http.StatusOK,
+			expect: `{"level":"warn","loggers":{"grpc":{"level":"error"}}}`,
+		},
+		{name: "invalid body", method: http.MethodPut, body: `{`, code: http.StatusBadRequest, expect: `could not decode request`},
+		{
+			name:   "too large body",
+			method: http.MethodPut,
+			body:   `{"logger":"` + strings.Repeat("a", maxLogLevelRequestSize) + `","level":"info"}`,
+			code:   http.StatusBadRequest,
+			expect: `request body too large`,
+		},
+		{name: "invalid level", method: http.MethodPut, body: `{"level":"unknown"}`, code: http.StatusBadRequest, expect: `unrecognized level`},
+		{name: "invalid ttl", method: http.MethodPut, body: `{"level":"info","ttl":"-1s"}`, code: http.StatusBadRequest, expect: `invalid ttl`},
+		{name: "unknown method", method: http.MethodPost, code: http.StatusMethodNotAllowed, expect: `method not allowed`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.method, tt.body)
+			require.Equal(t, tt.code, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.expect)
+		})
+	}
+
+	t.Run("set level with ttl", func(t *testing.T) {
+		rec := serve(http.MethodPut, `{"level":"error","ttl":"10ms"}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"expires":`)
+		require.Equal(t, zapcore.ErrorLevel, log.Levels().Level())
+
+		require.Eventually(t, func() bool {
+			return log.Levels().Level() == zapcore.WarnLevel
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should be nil without levels", func(t *testing.T) {
+		require.Nil(t, newLogLevelHandler(nil))
+	})
+}
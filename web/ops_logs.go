@@ -0,0 +1,159 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+type (
+	logsHandler struct {
+		log logger.Logger
+	}
+
+	// logsFilter describes query parameters, e.g. `?level=warn&since=5m&follow=1`.
+	logsFilter struct {
+		level  zapcore.Level
+		since  time.Time
+		follow bool
+	}
+)
+
+func newLogsHandler(log logger.Logger) http.Handler {
+	if log == nil || log.Buffer() == nil {
+		return nil
+	}
+
+	return &logsHandler{log: log}
+}
+
+// ServeHTTP returns recent entries as JSON lines or streams them as server-sent events, when follow is passed.
+func (h *logsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		h.reply(w, http.StatusMethodNotAllowed, "method not allowed")
+
+		return
+	}
+
+	filter, err := newLogsFilter(r)
+	if err != nil {
+		h.reply(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if filter.follow {
+		h.follow(w, r, filter)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	for _, entry := range h.log.Buffer().Entries(filter.level, filter.since) {
+		if err = enc.Encode(entry); err != nil {
+			h.log.Errorf("could not write response: %v", err)
+
+			return
+		}
+	}
+}
+
+func newLogsFilter(r *http.Request) (logsFilter, error) {
+	var (
+		err error
+		out = logsFilter{level: zapcore.DebugLevel}
+		req = r.URL.Query()
+	)
+
+	if value := req.Get("level"); value != "" {
+		if out.level, err = zapcore.ParseLevel(value); err != nil {
+			return out, err
+		}
+	}
+
+	if value := req.Get("since"); value != "" {
+		since, errParse := time.ParseDuration(value)
+		if errParse != nil || since < 0 {
+			return out, fmt.Errorf("invalid since %q", value)
+		}
+
+		out.since = time.Now().Add(-since)
+	}
+
+	if value := req.Get("follow"); value != "" {
+		if out.follow, err = strconv.ParseBool(value); err != nil {
+			return out, fmt.Errorf("invalid follow %q", value)
+		}
+	}
+
+	return out, nil
+}
+
+// follow streams kept and new entries as server-sent events until request will be canceled,
+// write deadline of the server is reset for the stream.
+func (h *logsHandler) follow(w http.ResponseWriter, r *http.Request, filter logsFilter) {
+	ctl := http.NewResponseController(w)
+	_ = ctl.SetWriteDeadline(time.Time{})
+
+	entries, stream, cancel := h.log.Buffer().Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(entry logger.BufferEntry) error {
+		if entry.Level < filter.level || entry.Time.Before(filter.since) {
+			return nil
+		}
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := send(entry); err != nil {
+			return
+		}
+	}
+
+	for {
+		if err := ctl.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case entry := <-stream:
+			if err := send(entry); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *logsHandler) reply(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": msg}); err != nil {
+		h.log.Errorf("could not write response: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/im-kulikov/go-bones/logger"
+)
+
+type fakeSink struct{ io.Writer }
+
+func (f *fakeSink) Close() error { return nil }
+
+func (f *fakeSink) Sync() error { return nil }
+
+func TestLogsHandler(t *testing.T) {
+	log, err := logger.New(logger.Config{Level: "info", Trace: "fatal", Buffer: logger.BufferConfig{Size: 10}},
+		logger.WithCustomOutput("ops-logs-test", &fakeSink{Writer: io.Discard}))
+	require.NoError(t, err)
+
+	handler := newLogsHandler(log)
+	require.NotNil(t, handler)
+
+	log.Info("first")
+	log.Warn("second")
+	log.Named("worker").Error("third")
+
+	serve := func(method, query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, "/debug/logs"+query, nil))
+
+		return rec
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		query  string
+		code   int
+		expect []string
+	}{
+		{name: "all entries", method: http.MethodGet, code: http.StatusOK, expect: []string{"first", "second", "third"}},
+		{name: "filter by level", method: http.MethodGet, query: "?level=warn", code: http.StatusOK, expect: []string{"second", "third"}},
+		{name: "filter by since", method: http.MethodGet, query: "?since=5m&level=error", code: http.StatusOK, expect: []string{"third"}},
+		{name: "invalid level", method: http.MethodGet, query: "?level=unknown", code: http.StatusBadRequest},
+		{name: "invalid since", method: http.MethodGet, query: "?since=-1s", code: http.StatusBadRequest},
+		{name: "invalid follow", method: http.MethodGet, query: "?follow=maybe", code: http.StatusBadRequest},
+		{name: "unknown method", method: http.MethodPost, code: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.method, tt.query)
+			require.Equal(t, tt.code, rec.Code)
+
+			if tt.code != http.StatusOK {
+				require.Contains(t, rec.Body.String(), `"error":`)
+
+				return
+			}
+
+			require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+			var actual []string
+			for _, line := range strings.Split(strings.TrimSpace(rec.Body.String()), "\n") {
+				var entry logger.BufferEntry
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+
+				actual = append(actual, entry.Message)
+			}
+
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+
+	t.Run("should stream entries", func(t *testing.T) {
+		srv := httptest.NewServer(handler)
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "?level=error&follow=1") // nolint:noctx
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, res.Body.Close()) }()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(res.Body)
+		next := func() logger.BufferEntry {
+			line, errRead := reader.ReadString('\n')
+			require.NoError(t, errRead)
+			require.True(t, strings.HasPrefix(line, "data: "))
+
+			empty, errRead := reader.ReadString('\n')
+			require.NoError(t, errRead)
+			require.Equal(t, "\n", empty)
+
+			var entry logger.BufferEntry
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &entry))
+
+			return entry
+		}
+
+		require.Equal(t, "third", next().Message)
+
+		log.Warn("skipped by level")
+		log.Errorw("streamed", "attempt", 1)
+
+		entry := next()
+		require.Equal(t, "streamed", entry.Message)
+		require.Equal(t, float64(1), entry.Fields["attempt"])
+		require.WithinDuration(t, time.Now(), entry.Time, time.Minute)
+	})
+
+	t.Run("should be nil without buffer", func(t *testing.T) {
+		require.Nil(t, newLogsHandler(nil))
+		require.Nil(t, newLogsHandler(logger.ForTests(t)))
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cristalhq/aconfig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/im-kulikov/go-bones/logger"
 	"github.com/im-kulikov/go-bones/service"
@@ -33,6 +34,7 @@ const (
 	opsRouteHealthy  = "/healthy"
 	opsRouteMetrics  = "/metrics"
 	opsRouteProfiler = "/debug/pprof"
+	opsRouteLogLevel = "/log/level"
 )
 
 func (f fakeHealthChecker) Name() string { return string(f) }
@@ -140,7 +142,7 @@ func TestEmptyHealthCheckers(t *testing.T) {
 }
 
 func TestNewOpsServer(t *testing.T) {
-	log := logger.ForTests(t)
+	log := logger.ForTestsObserved(t)
 	defer func() { require.NoError(t, log.Sync()) }()
 
 	lis, err := net.Listen(defaultOPSNetwork, "127.0.0.1:0")
@@ -175,6 +177,7 @@ func TestNewOpsServer(t *testing.T) {
 			opsRouteProfiler: containsComparer("Profile Descriptions"),
 			opsRouteMetrics:  containsComparer("go_memstats_frees_total"),
 			opsRouteHealthy:  containsComparer(`{"test":0,"test-with-error":1}`),
+			opsRouteLogLevel: containsComparer(`{"level":"debug"}`),
 		}
 
 		for route, comparer := range routes {
@@ -207,6 +210,9 @@ func TestNewOpsServer(t *testing.T) {
 		service.WithShutdownTimeout(time.Millisecond)).Run(ctx))
 
 	<-done // wait until stop ops server
+
+	log.AssertLogged(t, zapcore.ErrorLevel, "check service test-with-error failed with error: test-with-error")
+	require.Empty(t, log.FilterMessage("check service test failed with error: test"))
 }
 
 type bufferedOutput struct {